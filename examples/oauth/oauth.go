@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -83,7 +84,8 @@ func GetFanfouToken(w http.ResponseWriter, r *http.Request) {
 	})
 
 	if err != nil {
-		if fanfouErr, ok := err.(*fanfou.ErrorResponse); ok {
+		var fanfouErr *fanfou.ErrorResponse
+		if errors.As(err, &fanfouErr) {
 			fmt.Printf("%s\n", fanfouErr.GetFanfouError())
 			return
 		}
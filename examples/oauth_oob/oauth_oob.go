@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -12,7 +14,8 @@ func Usage() {
 	fmt.Println("Usage:")
 	fmt.Print("go run examples/oauth_oob/oauth_oob.go")
 	fmt.Print("  --consumerkey <consumerKey>")
-	fmt.Println("  --consumersecret <consumerSecret>")
+	fmt.Print("  --consumersecret <consumerSecret>")
+	fmt.Println("  [--tokenfile <tokenFile>]")
 	fmt.Println("")
 	fmt.Println("In order to get your consumerKey and consumerSecret, you must register an 'app' at fanfou.com:")
 	fmt.Println("https://fanfou.com/apps")
@@ -30,6 +33,11 @@ func main() {
 		"",
 		"Consumer Secret from Fanfou. See: https://fanfou.com/apps")
 
+	tokenFile := flag.String(
+		"tokenfile",
+		"fanfou_token.json",
+		"File the access token is saved to.")
+
 	flag.Parse()
 
 	if len(*consumerKey) == 0 || len(*consumerSecret) == 0 {
@@ -42,13 +50,17 @@ func main() {
 	// Step 1: initialize a new client
 	c := fanfou.NewClient(*consumerKey, *consumerSecret)
 
-	// Step 2: authorize the client
-	requestToken, URL, err := c.GetRequestTokenAndURL("oob")
+	// Step 2: authorize the client with the token saved by a previous run,
+	// or run the OAuth flow if there is none or it was revoked
+	store := fanfou.NewFileTokenStore(*tokenFile)
+
+	user, err := c.AuthorizeClientWithStore(store, authorize)
 	if err != nil {
-		// All go-fanfou errors are general errors of ErrorResponse type
+		// Errors returned by Fanfou are of ErrorResponse type
 		// You can either handle them as normal errors
-		// or assert the type and get precise fields like below
-		if fanfouErr, ok := err.(*fanfou.ErrorResponse); ok {
+		// or extract them and get precise fields like below
+		var fanfouErr *fanfou.ErrorResponse
+		if errors.As(err, &fanfouErr) {
 			fmt.Printf("authorize client error: %+v", fanfouErr.Error())
 			return
 		}
@@ -57,30 +69,13 @@ func main() {
 		return
 	}
 
-	fmt.Println("(1) Go to: " + URL)
-	fmt.Println("(2) Grant access, you should get back a verification code.")
-	fmt.Println("(3) Enter that verification code here: ")
-
-	verificationCode := ""
-	_, err = fmt.Scanln(&verificationCode)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-
-	accessToken, err := c.AuthorizeClient(requestToken, verificationCode)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-
-	// You can distinguish your users with the information in the accessToken
-	fmt.Println(accessToken)
+	fmt.Printf("Authorized as %s, the token is saved to %s\n", user.ScreenName, *tokenFile)
 
 	// Step 3: call the endpoints
 	resp, _, err := c.Statuses.HomeTimeline(&fanfou.StatusesOptParams{Count: 3, Format: "html"})
 	if err != nil {
-		if fanfouErr, ok := err.(*fanfou.ErrorResponse); ok {
+		var fanfouErr *fanfou.ErrorResponse
+		if errors.As(err, &fanfouErr) {
 			fmt.Printf("%s\n", fanfouErr.GetFanfouError())
 			return
 		}
@@ -91,3 +86,24 @@ func main() {
 
 	fmt.Printf("%+v\n", resp)
 }
+
+// authorize runs the OOB OAuth flow, asking the user for the verification
+// code
+func authorize(ctx context.Context, c *fanfou.Client) (*fanfou.AccessToken, error) {
+	requestToken, URL, err := c.GetRequestTokenAndURLContext(ctx, "oob")
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println("(1) Go to: " + URL)
+	fmt.Println("(2) Grant access, you should get back a verification code.")
+	fmt.Println("(3) Enter that verification code here: ")
+
+	verificationCode := ""
+	_, err = fmt.Scanln(&verificationCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.AuthorizeClientContext(ctx, requestToken, verificationCode)
+}
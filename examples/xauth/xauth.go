@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -55,12 +56,13 @@ func main() {
 	c := fanfou.NewClient(*consumerKey, *consumerSecret)
 
 	// Step 2: authorize the client
-	err := c.AuthorizeClientWithXAuth(*username, *password)
+	accessToken, err := c.AuthorizeClientWithXAuth(*username, *password)
 	if err != nil {
-		// All go-fanfou errors are general errors of ErrorResponse type
+		// Errors returned by Fanfou are of ErrorResponse type
 		// You can either handle them as normal errors
-		// or assert the type and get precise fields like below
-		if fanfouErr, ok := err.(*fanfou.ErrorResponse); ok {
+		// or extract them and get precise fields like below
+		var fanfouErr *fanfou.ErrorResponse
+		if errors.As(err, &fanfouErr) {
 			fmt.Printf("authorize client error: %+v", fanfouErr.GetFanfouError())
 			return
 		}
@@ -69,10 +71,15 @@ func main() {
 		return
 	}
 
+	// The access token can be stored to authorize the client later on,
+	// without the credentials
+	fmt.Println(accessToken)
+
 	// Step 3: call the endpoints
 	_, JSON, err := c.Friendships.Accept("asamiya", nil)
 	if err != nil {
-		if fanfouErr, ok := err.(*fanfou.ErrorResponse); ok {
+		var fanfouErr *fanfou.ErrorResponse
+		if errors.As(err, &fanfouErr) {
 			fmt.Printf("error: %s\n", fanfouErr.GetFanfouError())
 			return
 		}
@@ -1,6 +1,7 @@
 package fanfou
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -52,6 +53,12 @@ type AccountOptParams struct {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/account.verify-credentials
 func (s *AccountService) VerifyCredentials(opt *AccountOptParams) (*UserResult, *string, error) {
+	return s.VerifyCredentialsContext(context.Background(), opt)
+}
+
+// VerifyCredentialsContext is the same as VerifyCredentials, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *AccountService) VerifyCredentialsContext(ctx context.Context, opt *AccountOptParams) (*UserResult, *string, error) {
 	u := fmt.Sprintf("account/verify_credentials.json")
 	params := url.Values{}
 
@@ -66,7 +73,7 @@ func (s *AccountService) VerifyCredentials(opt *AccountOptParams) (*UserResult,
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -74,7 +81,7 @@ func (s *AccountService) VerifyCredentials(opt *AccountOptParams) (*UserResult,
 	newUser := new(UserResult)
 	resp, err := s.client.Do(req, newUser)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUser, resp.BodyStrPtr, nil
@@ -84,9 +91,15 @@ func (s *AccountService) VerifyCredentials(opt *AccountOptParams) (*UserResult,
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/account.rate-limit-status
 func (s *AccountService) RateLimitStatus() (*RateLimitStatusResult, *string, error) {
+	return s.RateLimitStatusContext(context.Background())
+}
+
+// RateLimitStatusContext is the same as RateLimitStatus, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *AccountService) RateLimitStatusContext(ctx context.Context) (*RateLimitStatusResult, *string, error) {
 	u := fmt.Sprintf("account/rate_limit_status.json")
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -94,7 +107,7 @@ func (s *AccountService) RateLimitStatus() (*RateLimitStatusResult, *string, err
 	newRateLimitStatus := new(RateLimitStatusResult)
 	resp, err := s.client.Do(req, newRateLimitStatus)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newRateLimitStatus, resp.BodyStrPtr, nil
@@ -104,6 +117,12 @@ func (s *AccountService) RateLimitStatus() (*RateLimitStatusResult, *string, err
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/account.update-profile
 func (s *AccountService) UpdateProfile(opt *AccountOptParams) (*UserResult, *string, error) {
+	return s.UpdateProfileContext(context.Background(), opt)
+}
+
+// UpdateProfileContext is the same as UpdateProfile, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *AccountService) UpdateProfileContext(ctx context.Context, opt *AccountOptParams) (*UserResult, *string, error) {
 	u := fmt.Sprintf("account/update_profile.json")
 	params := url.Values{}
 
@@ -128,7 +147,7 @@ func (s *AccountService) UpdateProfile(opt *AccountOptParams) (*UserResult, *str
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -136,7 +155,7 @@ func (s *AccountService) UpdateProfile(opt *AccountOptParams) (*UserResult, *str
 	newUser := new(UserResult)
 	resp, err := s.client.Do(req, newUser)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUser, resp.BodyStrPtr, nil
@@ -146,6 +165,12 @@ func (s *AccountService) UpdateProfile(opt *AccountOptParams) (*UserResult, *str
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/account.update-profile-image
 func (s *AccountService) UpdateProfileImage(filePath string, opt *AccountOptParams) (*UserResult, *string, error) {
+	return s.UpdateProfileImageContext(context.Background(), filePath, opt)
+}
+
+// UpdateProfileImageContext is the same as UpdateProfileImage, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *AccountService) UpdateProfileImageContext(ctx context.Context, filePath string, opt *AccountOptParams) (*UserResult, *string, error) {
 	u := fmt.Sprintf("account/update_profile_image.json")
 	params := map[string]string{}
 
@@ -158,7 +183,7 @@ func (s *AccountService) UpdateProfileImage(filePath string, opt *AccountOptPara
 		}
 	}
 
-	req, err := s.client.NewUploadRequest(http.MethodPost, u, params, "image", filePath)
+	req, err := s.client.NewUploadRequestContext(ctx, http.MethodPost, u, params, "image", filePath)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -166,7 +191,7 @@ func (s *AccountService) UpdateProfileImage(filePath string, opt *AccountOptPara
 	newUser := new(UserResult)
 	resp, err := s.client.Do(req, newUser)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUser, resp.BodyStrPtr, nil
@@ -177,9 +202,15 @@ func (s *AccountService) UpdateProfileImage(filePath string, opt *AccountOptPara
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/account.notification
 func (s *AccountService) Notification() (*NotificationResult, *string, error) {
+	return s.NotificationContext(context.Background())
+}
+
+// NotificationContext is the same as Notification, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *AccountService) NotificationContext(ctx context.Context) (*NotificationResult, *string, error) {
 	u := fmt.Sprintf("account/notification.json")
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -187,7 +218,7 @@ func (s *AccountService) Notification() (*NotificationResult, *string, error) {
 	newNotification := new(NotificationResult)
 	resp, err := s.client.Do(req, newNotification)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newNotification, resp.BodyStrPtr, nil
@@ -197,9 +228,15 @@ func (s *AccountService) Notification() (*NotificationResult, *string, error) {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/account.notify-num
 func (s *AccountService) NotifyNum() (*NotifyNumResult, *string, error) {
+	return s.NotifyNumContext(context.Background())
+}
+
+// NotifyNumContext is the same as NotifyNum, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *AccountService) NotifyNumContext(ctx context.Context) (*NotifyNumResult, *string, error) {
 	u := fmt.Sprintf("account/notify_num.json")
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -207,7 +244,7 @@ func (s *AccountService) NotifyNum() (*NotifyNumResult, *string, error) {
 	newNotifyNum := new(NotifyNumResult)
 	resp, err := s.client.Do(req, newNotifyNum)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newNotifyNum, resp.BodyStrPtr, nil
@@ -217,6 +254,12 @@ func (s *AccountService) NotifyNum() (*NotifyNumResult, *string, error) {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/account.update-notify-num
 func (s *AccountService) UpdateNotifyNum(opt *AccountOptParams) (*NotifyNumResult, *string, error) {
+	return s.UpdateNotifyNumContext(context.Background(), opt)
+}
+
+// UpdateNotifyNumContext is the same as UpdateNotifyNum, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *AccountService) UpdateNotifyNumContext(ctx context.Context, opt *AccountOptParams) (*NotifyNumResult, *string, error) {
 	u := fmt.Sprintf("account/update_notify_num.json")
 	params := url.Values{}
 
@@ -226,7 +269,7 @@ func (s *AccountService) UpdateNotifyNum(opt *AccountOptParams) (*NotifyNumResul
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -234,7 +277,7 @@ func (s *AccountService) UpdateNotifyNum(opt *AccountOptParams) (*NotifyNumResul
 	newNotifyNum := new(NotifyNumResult)
 	resp, err := s.client.Do(req, newNotifyNum)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newNotifyNum, resp.BodyStrPtr, nil
@@ -0,0 +1,253 @@
+package fanfou
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// ErrUnknownAccount is returned when no account of an Accounts matches a
+// user ID or screen name
+var ErrUnknownAccount = errors.New("fanfou: unknown account")
+
+// An Account is a client authorized as a user, see Accounts
+type Account struct {
+	// Client is the client authorized as the user
+	Client *Client
+
+	// User is the user, as verified when the account was added
+	User UserResult
+}
+
+// Accounts manages the clients of several users of the same application.
+// The clients share their consumer key, HTTP transport and RateLimiter,
+// which tracks the budget of every user on its own.
+//
+// Accounts is safe for concurrent use.
+type Accounts struct {
+	// RateLimiter shared by the clients of the accounts
+	RateLimiter *RateLimiter
+
+	consumerKey    string
+	consumerSecret string
+	opts           []Option
+
+	mu       sync.RWMutex
+	accounts []*Account
+}
+
+// NewAccounts returns an empty Accounts, whose clients are created with
+// the consumer key and secret and the options, and share limiter, or a new
+// RateLimiter if it is nil. Unless the options set one, the clients also
+// share a HTTP client.
+func NewAccounts(consumerKey, consumerSecret string, limiter *RateLimiter, opts ...Option) *Accounts {
+	if limiter == nil {
+		limiter = NewRateLimiter()
+	}
+
+	a := &Accounts{
+		RateLimiter:    limiter,
+		consumerKey:    consumerKey,
+		consumerSecret: consumerSecret,
+	}
+
+	// The options are applied in order: the HTTP client comes first so
+	// that it can be overridden, the limiter last so that it cannot
+	a.opts = append([]Option{WithHTTPClient(&http.Client{})}, opts...)
+	a.opts = append(a.opts, WithRateLimiter(limiter))
+
+	return a
+}
+
+// newClient returns a new client of the accounts, not authorized yet
+func (a *Accounts) newClient() *Client {
+	return NewClient(a.consumerKey, a.consumerSecret, a.opts...)
+}
+
+// Add shall authorize a new client with the access token, verify it with
+// account/verify_credentials and add it to the accounts, replacing the
+// account of the same user if any
+//
+// Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/account.verify-credentials
+func (a *Accounts) Add(token *AccessToken) (*Account, error) {
+	return a.AddContext(context.Background(), token)
+}
+
+// AddContext is the same as Add, except that the request is bound to ctx
+// and aborted once ctx is done
+func (a *Accounts) AddContext(ctx context.Context, token *AccessToken) (*Account, error) {
+	c := a.newClient()
+	if err := c.AuthorizeClientWithAccessTokens(token.Token, token.Secret, token.AdditionalData); err != nil {
+		return nil, err
+	}
+
+	user, _, err := c.Account.VerifyCredentialsContext(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &Account{Client: c, User: *user}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, existing := range a.accounts {
+		if existing.User.ID == user.ID {
+			a.accounts[i] = account
+			return account, nil
+		}
+	}
+	a.accounts = append(a.accounts, account)
+
+	return account, nil
+}
+
+// Get returns the account of the user of the given ID or, failing that,
+// of the given screen name
+func (a *Accounts) Get(key string) (*Account, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	i := a.index(key)
+	if i < 0 {
+		return nil, false
+	}
+
+	return a.accounts[i], true
+}
+
+// Remove removes the account of the user of the given ID or screen name,
+// and reports whether there was one
+func (a *Accounts) Remove(key string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	i := a.index(key)
+	if i < 0 {
+		return false
+	}
+
+	a.accounts = append(a.accounts[:i], a.accounts[i+1:]...)
+
+	return true
+}
+
+// List returns the accounts, in the order they were added
+func (a *Accounts) List() []*Account {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return append([]*Account(nil), a.accounts...)
+}
+
+// index returns the index of the account of the user of the given ID or
+// screen name, or -1. It shall be called with mu held.
+func (a *Accounts) index(key string) int {
+	for i, account := range a.accounts {
+		if account.User.ID == key {
+			return i
+		}
+	}
+
+	for i, account := range a.accounts {
+		if account.User.ScreenName == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// resolve returns the accounts of the given user IDs or screen names, or
+// all the accounts if none is given
+func (a *Accounts) resolve(keys []string) ([]*Account, error) {
+	if len(keys) == 0 {
+		return a.List(), nil
+	}
+
+	accounts := make([]*Account, 0, len(keys))
+	for _, key := range keys {
+		account, ok := a.Get(key)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownAccount, key)
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
+
+// Each calls fn concurrently with the accounts of the given user IDs or
+// screen names, or with all the accounts if none is given. It returns the
+// errors of the calls joined, or ErrUnknownAccount without calling fn if
+// a key matches no account.
+func (a *Accounts) Each(ctx context.Context, fn func(ctx context.Context, account *Account) error, keys ...string) error {
+	accounts, err := a.resolve(keys)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, len(accounts))
+
+	var wg sync.WaitGroup
+	for i, account := range accounts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(ctx, account); err != nil {
+				errs[i] = fmt.Errorf("account %s: %w", account.User.ID, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// PublishResult is the result of publishing a draft as an account, see
+// Accounts.Publish
+type PublishResult struct {
+	Account *Account
+	Status  *StatusResult
+	Err     error
+}
+
+// Publish shall post the draft as each of the accounts of the given user
+// IDs or screen names, or as all the accounts if none is given. The draft
+// is posted concurrently, and the results are returned in the order of
+// the accounts.
+//
+// Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.update
+func (a *Accounts) Publish(draft *Draft, keys ...string) ([]PublishResult, error) {
+	return a.PublishContext(context.Background(), draft, keys...)
+}
+
+// PublishContext is the same as Publish, except that the requests are
+// bound to ctx and aborted once ctx is done
+func (a *Accounts) PublishContext(ctx context.Context, draft *Draft, keys ...string) ([]PublishResult, error) {
+	if err := draft.Validate(); err != nil {
+		return nil, err
+	}
+
+	accounts, err := a.resolve(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]PublishResult, len(accounts))
+
+	var wg sync.WaitGroup
+	for i, account := range accounts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, _, err := account.Client.Statuses.PublishContext(ctx, draft)
+			results[i] = PublishResult{Account: account, Status: status, Err: err}
+		}()
+	}
+	wg.Wait()
+
+	return results, nil
+}
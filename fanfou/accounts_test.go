@@ -0,0 +1,166 @@
+package fanfou
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// testTokenPattern matches the access token of a signed request
+var testTokenPattern = regexp.MustCompile(`oauth_token="([^"]*)"`)
+
+// testTokenOf returns the access token a request is signed with
+func testTokenOf(r *http.Request) string {
+	match := testTokenPattern.FindStringSubmatch(r.Header.Get("Authorization"))
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
+
+func newTestAccounts(t *testing.T) *Accounts {
+	mux.HandleFunc("/account/verify_credentials.json", func(w http.ResponseWriter, r *http.Request) {
+		switch testTokenOf(r) {
+		case "token_brand":
+			fmt.Fprint(w, `{"id": "brand_id", "screen_name": "Brand"}`)
+		case "token_bot":
+			fmt.Fprint(w, `{"id": "bot_id", "screen_name": "Bot"}`)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": "test error"}`)
+		}
+	})
+
+	accounts := NewAccounts("test", "test", nil, WithBaseURL(serverURL), WithAuthBaseURL(serverURL))
+
+	for _, token := range []string{"token_brand", "token_bot"} {
+		if _, err := accounts.Add(&AccessToken{Token: token, Secret: "test"}); err != nil {
+			t.Fatalf("accounts.add returned error: %v", err)
+		}
+	}
+
+	return accounts
+}
+
+func TestAccounts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	accounts := newTestAccounts(t)
+
+	if _, err := accounts.Add(&AccessToken{Token: "token_revoked", Secret: "test"}); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("accounts.add of a revoked token returned %v, want ErrUnauthorized", err)
+	}
+
+	brand, ok := accounts.Get("brand_id")
+	if !ok || brand.User.ScreenName != "Brand" {
+		t.Fatalf("accounts.get by ID returned %+v, want the Brand account", brand)
+	}
+
+	if bot, ok := accounts.Get("Bot"); !ok || bot.User.ID != "bot_id" {
+		t.Errorf("accounts.get by screen name returned %+v, want the Bot account", bot)
+	}
+
+	if brand.Client.RateLimiter != accounts.RateLimiter {
+		t.Errorf("account client has rate limiter %p, want the shared %p", brand.Client.RateLimiter, accounts.RateLimiter)
+	}
+
+	// The limiter given is shared even if the options set another one
+	limiter := NewRateLimiter()
+	if c := NewAccounts("test", "test", limiter, WithRateLimiter(NewRateLimiter())).newClient(); c.RateLimiter != limiter {
+		t.Errorf("account client has rate limiter %p, want the given %p", c.RateLimiter, limiter)
+	}
+
+	// Adding the same user again replaces its account
+	if _, err := accounts.Add(&AccessToken{Token: "token_brand", Secret: "test"}); err != nil {
+		t.Fatalf("accounts.add returned error: %v", err)
+	}
+	if n := len(accounts.List()); n != 2 {
+		t.Errorf("accounts.list returned %d accounts, want %d", n, 2)
+	}
+
+	if !accounts.Remove("Brand") {
+		t.Errorf("accounts.remove returned false, want true")
+	}
+	if _, ok := accounts.Get("brand_id"); ok {
+		t.Errorf("accounts.get of a removed account returned it")
+	}
+	if accounts.Remove("Brand") {
+		t.Errorf("accounts.remove of a removed account returned true, want false")
+	}
+}
+
+func TestAccounts_Publish(t *testing.T) {
+	setup()
+	defer teardown()
+
+	accounts := newTestAccounts(t)
+
+	var mu sync.Mutex
+	var posters []string
+	mux.HandleFunc("/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"status": "hello"})
+
+		mu.Lock()
+		posters = append(posters, testTokenOf(r))
+		mu.Unlock()
+
+		fmt.Fprintf(w, `{"id": "status_of_%s"}`, testTokenOf(r))
+	})
+
+	results, err := accounts.Publish(NewDraft("hello"), "Bot", "brand_id")
+	if err != nil {
+		t.Fatalf("accounts.publish returned error: %v", err)
+	}
+
+	want := []string{"status_of_token_bot", "status_of_token_brand"}
+	for i, result := range results {
+		if result.Err != nil || result.Status.ID != want[i] {
+			t.Errorf("accounts.publish returned %+v for account %d, want status %v", result, i, want[i])
+		}
+	}
+
+	sort.Strings(posters)
+	if len(posters) != 2 || posters[0] != "token_bot" || posters[1] != "token_brand" {
+		t.Errorf("statuses.update was sent with tokens %v, want the 2 accounts", posters)
+	}
+
+	if _, err := accounts.Publish(NewDraft("hello"), "nobody"); !errors.Is(err, ErrUnknownAccount) {
+		t.Errorf("accounts.publish to an unknown account returned %v, want ErrUnknownAccount", err)
+	}
+}
+
+func TestAccounts_Each(t *testing.T) {
+	setup()
+	defer teardown()
+
+	accounts := newTestAccounts(t)
+
+	var mu sync.Mutex
+	seen := map[string]bool{}
+
+	err := accounts.Each(context.Background(), func(ctx context.Context, account *Account) error {
+		mu.Lock()
+		seen[account.User.ID] = true
+		mu.Unlock()
+
+		if account.User.ID == "bot_id" {
+			return errors.New("test error")
+		}
+		return nil
+	})
+
+	if len(seen) != 2 {
+		t.Errorf("accounts.each called fn with %v, want all the accounts", seen)
+	}
+
+	if err == nil || err.Error() != "account bot_id: test error" {
+		t.Errorf("accounts.each returned %v, want the error of bot_id", err)
+	}
+}
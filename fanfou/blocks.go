@@ -1,6 +1,7 @@
 package fanfou
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -33,9 +34,15 @@ type BlocksOptParams struct {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/blocks.ids
 func (s *BlocksService) IDs() (*UserIDs, *string, error) {
+	return s.IDsContext(context.Background())
+}
+
+// IDsContext is the same as IDs, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *BlocksService) IDsContext(ctx context.Context) (*UserIDs, *string, error) {
 	u := fmt.Sprintf("blocks/ids.json")
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -43,7 +50,7 @@ func (s *BlocksService) IDs() (*UserIDs, *string, error) {
 	newUserIDs := new(UserIDs)
 	resp, err := s.client.Do(req, newUserIDs)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUserIDs, resp.BodyStrPtr, nil
@@ -53,6 +60,12 @@ func (s *BlocksService) IDs() (*UserIDs, *string, error) {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/blocks.blocking
 func (s *BlocksService) Blocking(opt *BlocksOptParams) ([]UserResult, *string, error) {
+	return s.BlockingContext(context.Background(), opt)
+}
+
+// BlockingContext is the same as Blocking, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *BlocksService) BlockingContext(ctx context.Context, opt *BlocksOptParams) ([]UserResult, *string, error) {
 	u := fmt.Sprintf("blocks/blocking.json")
 	params := url.Values{}
 
@@ -70,7 +83,7 @@ func (s *BlocksService) Blocking(opt *BlocksOptParams) ([]UserResult, *string, e
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -78,7 +91,7 @@ func (s *BlocksService) Blocking(opt *BlocksOptParams) ([]UserResult, *string, e
 	newUsers := new([]UserResult)
 	resp, err := s.client.Do(req, newUsers)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newUsers, resp.BodyStrPtr, nil
@@ -91,6 +104,12 @@ func (s *BlocksService) Blocking(opt *BlocksOptParams) ([]UserResult, *string, e
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/blocks.exists
 func (s *BlocksService) Exists(ID string, opt *BlocksOptParams) (*UserResult, *string, error) {
+	return s.ExistsContext(context.Background(), ID, opt)
+}
+
+// ExistsContext is the same as Exists, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *BlocksService) ExistsContext(ctx context.Context, ID string, opt *BlocksOptParams) (*UserResult, *string, error) {
 	u := fmt.Sprintf("blocks/exists.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -104,7 +123,7 @@ func (s *BlocksService) Exists(ID string, opt *BlocksOptParams) (*UserResult, *s
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -112,7 +131,7 @@ func (s *BlocksService) Exists(ID string, opt *BlocksOptParams) (*UserResult, *s
 	newUser := new(UserResult)
 	resp, err := s.client.Do(req, newUser)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUser, resp.BodyStrPtr, nil
@@ -124,6 +143,12 @@ func (s *BlocksService) Exists(ID string, opt *BlocksOptParams) (*UserResult, *s
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/blocks.create
 func (s *BlocksService) Create(ID string, opt *BlocksOptParams) (*UserResult, *string, error) {
+	return s.CreateContext(context.Background(), ID, opt)
+}
+
+// CreateContext is the same as Create, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *BlocksService) CreateContext(ctx context.Context, ID string, opt *BlocksOptParams) (*UserResult, *string, error) {
 	u := fmt.Sprintf("blocks/create.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -138,7 +163,7 @@ func (s *BlocksService) Create(ID string, opt *BlocksOptParams) (*UserResult, *s
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -146,7 +171,7 @@ func (s *BlocksService) Create(ID string, opt *BlocksOptParams) (*UserResult, *s
 	newUser := new(UserResult)
 	resp, err := s.client.Do(req, newUser)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUser, resp.BodyStrPtr, nil
@@ -158,6 +183,12 @@ func (s *BlocksService) Create(ID string, opt *BlocksOptParams) (*UserResult, *s
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/blocks.destroy
 func (s *BlocksService) Destroy(ID string, opt *BlocksOptParams) (*UserResult, *string, error) {
+	return s.DestroyContext(context.Background(), ID, opt)
+}
+
+// DestroyContext is the same as Destroy, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *BlocksService) DestroyContext(ctx context.Context, ID string, opt *BlocksOptParams) (*UserResult, *string, error) {
 	u := fmt.Sprintf("blocks/destroy.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -169,7 +200,7 @@ func (s *BlocksService) Destroy(ID string, opt *BlocksOptParams) (*UserResult, *
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -177,7 +208,7 @@ func (s *BlocksService) Destroy(ID string, opt *BlocksOptParams) (*UserResult, *
 	newUser := new(UserResult)
 	resp, err := s.client.Do(req, newUser)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUser, resp.BodyStrPtr, nil
@@ -0,0 +1,177 @@
+package fanfou
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// callbackPath is the path of the callback URL of a CallbackServer
+const callbackPath = "/callback"
+
+// ErrCallbackTokenMismatch is returned when a callback carries another
+// token than the request token being authorized
+var ErrCallbackTokenMismatch = errors.New("fanfou: callback token does not match the request token")
+
+// callback is a redirect received by a CallbackServer, answered once the
+// waiting side checked it
+type callback struct {
+	token    string
+	verifier string
+	reply    chan error
+}
+
+// A CallbackServer is a HTTP server on the loopback interface receiving
+// the redirect of the browser OAuth flow, for applications without a web
+// server of their own
+type CallbackServer struct {
+	// URL is the callback URL to pass to GetRequestTokenAndURL
+	URL string
+
+	listener  net.Listener
+	server    *http.Server
+	callbacks chan callback
+}
+
+// NewCallbackServer starts a CallbackServer on a free port of the loopback
+// interface. It shall be closed once the flow is done.
+func NewCallbackServer() (*CallbackServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &CallbackServer{
+		URL:       "http://" + listener.Addr().String() + callbackPath,
+		listener:  listener,
+		callbacks: make(chan callback),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, s.handle)
+	s.server = &http.Server{Handler: mux}
+
+	go s.server.Serve(listener)
+
+	return s, nil
+}
+
+// handle passes the callback to Wait, and tells the user whether it was
+// accepted. As the access token is only exchanged afterwards, the page does
+// not claim that the authorization succeeded.
+func (s *CallbackServer) handle(w http.ResponseWriter, r *http.Request) {
+	cb := callback{
+		token:    r.URL.Query().Get("oauth_token"),
+		verifier: r.URL.Query().Get("oauth_verifier"),
+		reply:    make(chan error, 1),
+	}
+
+	var err error
+	select {
+	case s.callbacks <- cb:
+		err = <-cb.reply
+	case <-r.Context().Done():
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "<!DOCTYPE html><p>Authorization failed: %s</p>", html.EscapeString(err.Error()))
+		return
+	}
+
+	fmt.Fprint(w, "<!DOCTYPE html><p>Authorization received, you can close this window and return to the application.</p>")
+}
+
+// Wait waits for the callback of requestToken until ctx is done, and
+// returns its verifier. Callbacks of other tokens are answered with an
+// error page and ignored.
+func (s *CallbackServer) Wait(ctx context.Context, requestToken *RequestToken) (string, error) {
+	for {
+		select {
+		case cb := <-s.callbacks:
+			if cb.token != requestToken.Token {
+				cb.reply <- ErrCallbackTokenMismatch
+				continue
+			}
+			cb.reply <- nil
+			return cb.verifier, nil
+
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+// Close stops the server
+func (s *CallbackServer) Close() error {
+	return s.server.Close()
+}
+
+// AuthorizeClientInBrowser runs the browser OAuth flow with a
+// CallbackServer: it gets a request token, calls open with the login URL,
+// e.g. to launch a browser or print the URL, waits for the redirect of
+// Fanfou once the user granted access, and completes the authorization
+// with AuthorizeClient.
+func (c *Client) AuthorizeClientInBrowser(open func(loginURL string) error) (*AccessToken, error) {
+	return c.AuthorizeClientInBrowserContext(context.Background(), open)
+}
+
+// AuthorizeClientInBrowserContext is the same as AuthorizeClientInBrowser,
+// except that the requests and the wait are bound to ctx and aborted once
+// ctx is done. Use a ctx with a timeout to give up on users who never come
+// back.
+func (c *Client) AuthorizeClientInBrowserContext(ctx context.Context, open func(loginURL string) error) (*AccessToken, error) {
+	server, err := NewCallbackServer()
+	if err != nil {
+		return nil, err
+	}
+	defer server.Close()
+
+	requestToken, loginURL, err := c.GetRequestTokenAndURLContext(ctx, server.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := open(withCallback(loginURL, server.URL)); err != nil {
+		return nil, err
+	}
+
+	verifier, err := server.Wait(ctx, requestToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.AuthorizeClientContext(ctx, requestToken, verifier)
+}
+
+// withCallback adds the callback URL to the login URL, which Fanfou reads
+// there as OAuth 1.0 specifies, unless it is already set
+func withCallback(loginURL, callbackURL string) string {
+	u, err := url.Parse(loginURL)
+	if err != nil {
+		return loginURL
+	}
+
+	q := u.Query()
+	if q.Get("oauth_callback") != "" {
+		return loginURL
+	}
+	q.Set("oauth_callback", callbackURL)
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+// BrowserAuthFlow returns an AuthFlow running AuthorizeClientInBrowser,
+// see Client.AuthorizeClientWithStore
+func BrowserAuthFlow(open func(loginURL string) error) AuthFlow {
+	return func(ctx context.Context, c *Client) (*AccessToken, error) {
+		return c.AuthorizeClientInBrowserContext(ctx, open)
+	}
+}
@@ -0,0 +1,83 @@
+package fanfou
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_AuthorizeClientInBrowser(t *testing.T) {
+	setup()
+	defer teardown()
+
+	statuses := make(chan int, 2)
+
+	// The browser is redirected with another token first, then with the
+	// request token
+	open := func(loginURL string) error {
+		u, err := url.Parse(loginURL)
+		if err != nil {
+			return err
+		}
+
+		callbackURL := u.Query().Get("oauth_callback")
+		if !strings.HasPrefix(callbackURL, "http://127.0.0.1:") {
+			t.Errorf("login URL %v has callback %v, want a loopback URL", loginURL, callbackURL)
+		}
+
+		go func() {
+			for _, token := range []string{"other_token", "test_token"} {
+				resp, err := http.Get(callbackURL + "?oauth_token=" + token + "&oauth_verifier=test_verifier")
+				if err != nil {
+					t.Errorf("callback returned error: %v", err)
+					return
+				}
+				body, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+
+				// The access token is not exchanged yet
+				if strings.Contains(string(body), "succeeded") {
+					t.Errorf("callback page %q claims the authorization succeeded", body)
+				}
+				statuses <- resp.StatusCode
+			}
+		}()
+
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	token, err := client.AuthorizeClientInBrowserContext(ctx, open)
+	if err != nil {
+		t.Fatalf("AuthorizeClientInBrowser returned error: %v", err)
+	}
+
+	want := &AccessToken{Token: "test", Secret: "test", AdditionalData: map[string]string{}}
+	if !reflect.DeepEqual(token, want) {
+		t.Errorf("AuthorizeClientInBrowser returned %+v, want %+v", token, want)
+	}
+
+	if got := []int{<-statuses, <-statuses}; !reflect.DeepEqual(got, []int{http.StatusBadRequest, http.StatusOK}) {
+		t.Errorf("callbacks were answered with %v, want %v", got, []int{http.StatusBadRequest, http.StatusOK})
+	}
+}
+
+func TestClient_AuthorizeClientInBrowserTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.AuthorizeClientInBrowserContext(ctx, func(string) error { return nil })
+	if err != context.DeadlineExceeded {
+		t.Errorf("AuthorizeClientInBrowser returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,204 @@
+package fanfou
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxStatusLength is the maximum length of a status, in characters
+const MaxStatusLength = 140
+
+// ellipsis ends the quoted text of a repost when it is truncated
+const ellipsis = "…"
+
+var (
+	// ErrEmptyStatus is returned when a Draft has no text, or is nil
+	ErrEmptyStatus = errors.New("fanfou: empty status")
+
+	// ErrMissingReplyContext is returned when a Draft replies to a user
+	// without the status it replies to
+	ErrMissingReplyContext = errors.New("fanfou: reply to a user without the status replied to")
+
+	// ErrMissingStatus is returned when a Draft replies to or reposts a
+	// nil status
+	ErrMissingStatus = errors.New("fanfou: reply to or repost of a missing status")
+)
+
+// StatusTooLongError is returned when the text of a Draft is longer than
+// MaxStatusLength
+type StatusTooLongError struct {
+	// Length is the length of the text, see StatusLength
+	Length int
+}
+
+// Error implements the error interface
+func (e *StatusTooLongError) Error() string {
+	return fmt.Sprintf("fanfou: status is %d characters long, the maximum is %d", e.Length, MaxStatusLength)
+}
+
+// StatusLength returns the length of a status text as Fanfou counts it:
+// every character counts as one, CJK characters and emoji included, and
+// the surrounding whitespace is ignored
+func StatusLength(text string) int {
+	return utf8.RuneCountInString(strings.TrimSpace(text))
+}
+
+// A Draft is a status about to be posted with StatusesService.Publish,
+// which validates it before sending it
+type Draft struct {
+	// Text is the text of the status
+	Text string
+
+	// InReplyToStatusID is the ID of the status replied to, if any
+	InReplyToStatusID string
+
+	// InReplyToUserID is the ID of the author of the status replied to
+	InReplyToUserID string
+
+	// RepostStatusID is the ID of the status reposted, if any
+	RepostStatusID string
+
+	// Source is the name of the application posting the status
+	Source string
+
+	// Location is the location of the author
+	Location string
+
+	// err is the error of the construction of the draft, if any
+	err error
+}
+
+// NewDraft returns a Draft of a new status
+func NewDraft(text string) *Draft {
+	return &Draft{Text: text}
+}
+
+// ReplyTo returns a Draft replying to the status, mentioning its author in
+// front of text, e.g. "@name text", unless text already starts with it.
+// If status is nil, the draft fails to validate with ErrMissingStatus.
+func ReplyTo(status *StatusResult, text string) *Draft {
+	if status == nil {
+		return &Draft{Text: text, err: ErrMissingStatus}
+	}
+
+	author := status.GetUser()
+
+	if name := author.GetScreenName(); name != "" {
+		mention := "@" + name + " "
+		text = mention + strings.TrimPrefix(text, mention)
+	}
+
+	return &Draft{
+		Text:              text,
+		InReplyToStatusID: status.ID,
+		InReplyToUserID:   author.GetID(),
+	}
+}
+
+// RepostOf returns a Draft reposting the status with a comment, quoting it
+// after the comment as Fanfou does, e.g. "comment 转@name text". The quoted
+// text is truncated if needed to fit MaxStatusLength, the comment never is.
+// If status is nil, the draft fails to validate with ErrMissingStatus.
+func RepostOf(status *StatusResult, comment string) *Draft {
+	if status == nil {
+		return &Draft{Text: comment, err: ErrMissingStatus}
+	}
+
+	prefix := strings.TrimSpace(comment)
+	if prefix != "" {
+		prefix += " "
+	}
+	prefix += "转@" + status.GetUser().GetScreenName() + " "
+
+	quote := ParseRichText(status.Text).PlainText()
+	if room := MaxStatusLength - utf8.RuneCountInString(prefix); utf8.RuneCountInString(quote) > room {
+		quote = truncate(quote, room-1) + ellipsis
+	}
+
+	return &Draft{
+		Text:           prefix + quote,
+		RepostStatusID: status.ID,
+	}
+}
+
+// truncate returns the first n characters of s
+func truncate(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+
+	i := 0
+	for j := range s {
+		if i == n {
+			return s[:j]
+		}
+		i++
+	}
+
+	return s
+}
+
+// Length returns the length of the text of the draft, see StatusLength
+func (d *Draft) Length() int {
+	return StatusLength(d.Text)
+}
+
+// Validate checks that the draft can be posted, returning ErrEmptyStatus,
+// ErrMissingReplyContext, ErrMissingStatus or a *StatusTooLongError
+// otherwise
+func (d *Draft) Validate() error {
+	if d == nil {
+		return ErrEmptyStatus
+	}
+
+	if d.err != nil {
+		return d.err
+	}
+
+	length := d.Length()
+	if length == 0 {
+		return ErrEmptyStatus
+	}
+
+	if length > MaxStatusLength {
+		return &StatusTooLongError{Length: length}
+	}
+
+	if d.InReplyToUserID != "" && d.InReplyToStatusID == "" {
+		return ErrMissingReplyContext
+	}
+
+	return nil
+}
+
+// params returns the optional params of statuses/update for the draft
+func (d *Draft) params() *StatusesOptParams {
+	return &StatusesOptParams{
+		InReplyToStatusID: d.InReplyToStatusID,
+		InReplyToUserID:   d.InReplyToUserID,
+		RepostStatusID:    d.RepostStatusID,
+		Source:            d.Source,
+		Location:          d.Location,
+	}
+}
+
+// Publish shall validate the draft and post it as a new status.
+// Nothing is sent if the draft is not valid.
+//
+// Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.update
+func (s *StatusesService) Publish(draft *Draft) (*StatusResult, *string, error) {
+	return s.PublishContext(context.Background(), draft)
+}
+
+// PublishContext is the same as Publish, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *StatusesService) PublishContext(ctx context.Context, draft *Draft) (*StatusResult, *string, error) {
+	if err := draft.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return s.UpdateContext(ctx, strings.TrimSpace(draft.Text), draft.params())
+}
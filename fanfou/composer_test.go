@@ -0,0 +1,161 @@
+package fanfou
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStatusLength(t *testing.T) {
+	tests := map[string]int{
+		"":            0,
+		"  hello  ":   5,
+		"饭否":          2,
+		"饭否 fanfou 🍚": 11,
+	}
+
+	for text, want := range tests {
+		if got := StatusLength(text); got != want {
+			t.Errorf("StatusLength(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+func TestDraft_Validate(t *testing.T) {
+	tests := []struct {
+		draft *Draft
+		want  error
+	}{
+		{NewDraft(strings.Repeat("饭", MaxStatusLength)), nil},
+		{NewDraft("   "), ErrEmptyStatus},
+		{&Draft{Text: "test", InReplyToUserID: "test_user_id"}, ErrMissingReplyContext},
+	}
+
+	for _, tt := range tests {
+		if err := tt.draft.Validate(); err != tt.want {
+			t.Errorf("Draft.Validate() of %+v returned %v, want %v", tt.draft, err, tt.want)
+		}
+	}
+
+	err := NewDraft(strings.Repeat("饭", MaxStatusLength+1)).Validate()
+
+	var tooLongErr *StatusTooLongError
+	if !errors.As(err, &tooLongErr) || tooLongErr.Length != MaxStatusLength+1 {
+		t.Errorf("Draft.Validate() returned %v, want StatusTooLongError of length %d", err, MaxStatusLength+1)
+	}
+}
+
+func TestReplyTo(t *testing.T) {
+	status := &StatusResult{
+		ID:   "test_id",
+		User: &UserResult{ID: "test_user_id", ScreenName: "测试"},
+	}
+
+	want := &Draft{
+		Text:              "@测试 你好",
+		InReplyToStatusID: "test_id",
+		InReplyToUserID:   "test_user_id",
+	}
+
+	if got := ReplyTo(status, "你好"); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReplyTo returned %+v, want %+v", got, want)
+	}
+
+	if got := ReplyTo(status, "@测试 你好"); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReplyTo returned %+v, want %+v", got, want)
+	}
+}
+
+func TestRepostOf(t *testing.T) {
+	status := &StatusResult{
+		ID:   "test_id",
+		Text: `hello &amp; #<a href="/q/tag">tag</a>#`,
+		User: &UserResult{ID: "test_user_id", ScreenName: "测试"},
+	}
+
+	want := &Draft{
+		Text:           "好 转@测试 hello & #tag#",
+		RepostStatusID: "test_id",
+	}
+
+	if got := RepostOf(status, "好"); !reflect.DeepEqual(got, want) {
+		t.Errorf("RepostOf returned %+v, want %+v", got, want)
+	}
+
+	status.Text = strings.Repeat("饭", MaxStatusLength)
+	draft := RepostOf(status, "好")
+
+	if draft.Length() != MaxStatusLength {
+		t.Errorf("RepostOf of a long status has length %d, want %d", draft.Length(), MaxStatusLength)
+	}
+
+	if !strings.HasPrefix(draft.Text, "好 转@测试 饭") || !strings.HasSuffix(draft.Text, "饭"+ellipsis) {
+		t.Errorf("RepostOf of a long status returned %q, want a truncated quote", draft.Text)
+	}
+}
+
+func TestReplyToAndRepostOf_nilStatus(t *testing.T) {
+	for name, draft := range map[string]*Draft{
+		"ReplyTo":  ReplyTo(nil, "你好"),
+		"RepostOf": RepostOf(nil, "好"),
+	} {
+		if err := draft.Validate(); err != ErrMissingStatus {
+			t.Errorf("%s of a nil status validated with %v, want %v", name, err, ErrMissingStatus)
+		}
+	}
+}
+
+func TestStatusesService_PublishNilDraft(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("statuses.update was sent for a nil draft")
+	})
+
+	if _, _, err := client.Statuses.Publish(nil); err != ErrEmptyStatus {
+		t.Errorf("statuses.publish of a nil draft returned %v, want %v", err, ErrEmptyStatus)
+	}
+}
+
+func TestStatusesService_Publish(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{
+			"status":                "@test_name test",
+			"in_reply_to_status_id": "test_id",
+			"in_reply_to_user_id":   "test_user_id",
+		})
+		_, err := fmt.Fprint(w, `{"id": "test_reply_id"}`)
+		if err != nil {
+			t.Errorf("statuses.update mock server error: %+v", err)
+		}
+	})
+
+	status := &StatusResult{ID: "test_id", User: &UserResult{ID: "test_user_id", ScreenName: "test_name"}}
+
+	reply, _, err := client.Statuses.Publish(ReplyTo(status, "test"))
+	if err != nil {
+		t.Fatalf("statuses.publish returned error: %v", err)
+	}
+
+	if reply.ID != "test_reply_id" {
+		t.Errorf("statuses.publish returned %+v, want ID %v", reply, "test_reply_id")
+	}
+
+	if _, _, err := client.Statuses.Publish(NewDraft(strings.Repeat("饭", MaxStatusLength+1))); err == nil {
+		t.Errorf("statuses.publish of a long status returned no error")
+	}
+
+	if calls != 1 {
+		t.Errorf("statuses.update was sent %d times, want %d", calls, 1)
+	}
+}
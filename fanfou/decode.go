@@ -0,0 +1,379 @@
+package fanfou
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSnippetLen is the maximum length of the body snippet of a DecodeError
+const maxSnippetLen = 256
+
+// jsonUnmarshalerType is the type of the values decoding themselves
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// WithLenientDecoding makes the client tolerate the inconsistent types of
+// some Fanfou responses, e.g. quoted numbers or "true" and "false" strings,
+// instead of failing with a DecodeError
+func WithLenientDecoding(enabled bool) Option {
+	return func(c *Client) {
+		c.lenient = enabled
+	}
+}
+
+// DecodeError is returned when the body of a successful response cannot be
+// decoded into the result type of the endpoint. The response is returned
+// along with it.
+type DecodeError struct {
+	// Endpoint is the API endpoint, e.g. "statuses/home_timeline"
+	Endpoint string
+
+	// Body is a snippet of the body around the offending value
+	Body string
+
+	// Field is the path of the offending field, e.g. "user.protected", or
+	// an empty string if unknown
+	Field string
+
+	// Err is the error of the decoder
+	Err error
+}
+
+// Error implements the error interface
+func (e *DecodeError) Error() string {
+	msg := "decoding " + e.Endpoint + " response"
+	if e.Field != "" {
+		msg += ", field " + e.Field
+	}
+
+	return msg + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error of the decoder
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// newDecodeError returns the DecodeError of the body of the endpoint
+func newDecodeError(endpoint string, body []byte, err error) *DecodeError {
+	decodeErr := &DecodeError{
+		Endpoint: endpoint,
+		Err:      err,
+	}
+
+	offset := int64(0)
+
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr):
+		decodeErr.Field = typeErr.Field
+		offset = typeErr.Offset
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	}
+
+	decodeErr.Body = snippet(body, int(offset))
+
+	return decodeErr
+}
+
+// snippet returns at most maxSnippetLen bytes of body around offset,
+// without splitting any character
+func snippet(body []byte, offset int) string {
+	if len(body) <= maxSnippetLen {
+		return string(body)
+	}
+
+	start := offset - maxSnippetLen/2
+	if start < 0 {
+		start = 0
+	}
+
+	end := start + maxSnippetLen
+	if end > len(body) {
+		end = len(body)
+		start = end - maxSnippetLen
+	}
+
+	for start > 0 && !utf8.RuneStart(body[start]) {
+		start--
+	}
+
+	for end < len(body) && !utf8.RuneStart(body[end]) {
+		end--
+	}
+
+	return string(body[start:end])
+}
+
+// decodeJSON decodes the JSON data into v. If lenient, the values whose
+// type does not match the one of their field are converted if possible.
+func decodeJSON(data []byte, v interface{}, lenient bool) error {
+	err := json.Unmarshal(data, v)
+
+	var typeErr *json.UnmarshalTypeError
+	if err == nil || !lenient || !errors.As(err, &typeErr) {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if decoder.Decode(&value) != nil {
+		return err
+	}
+
+	coerced, marshalErr := json.Marshal(coerce(value, reflect.TypeOf(v)))
+	if marshalErr != nil {
+		return err
+	}
+
+	// The original error is more meaningful than the one of the coerced
+	// data, which is only tried as a fallback
+	if json.Unmarshal(coerced, v) != nil {
+		return err
+	}
+
+	return nil
+}
+
+// coerce converts the decoded JSON value so it can be decoded into a value
+// of type t, as far as possible
+func coerce(value interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		if t.Implements(jsonUnmarshalerType) {
+			return value
+		}
+		t = t.Elem()
+	}
+
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return value
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return coerceBool(value)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return coerceNumber(value)
+
+	case reflect.String:
+		switch v := value.(type) {
+		case json.Number:
+			return v.String()
+		case bool:
+			return strconv.FormatBool(v)
+		}
+
+	case reflect.Slice, reflect.Array:
+		if values, ok := value.([]interface{}); ok {
+			for i := range values {
+				values[i] = coerce(values[i], t.Elem())
+			}
+		}
+
+	case reflect.Map:
+		if values, ok := value.(map[string]interface{}); ok {
+			for key := range values {
+				values[key] = coerce(values[key], t.Elem())
+			}
+		}
+
+	case reflect.Struct:
+		if values, ok := value.(map[string]interface{}); ok {
+			for key := range values {
+				if field, ok := jsonField(t, key); ok {
+					values[key] = coerce(values[key], field.Type)
+				}
+			}
+		}
+	}
+
+	return value
+}
+
+// coerceBool converts the quoted booleans and the numbers to booleans
+func coerceBool(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+			return b
+		}
+		if strings.TrimSpace(v) == "" {
+			return false
+		}
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f != 0
+		}
+	}
+
+	return value
+}
+
+// coerceNumber converts the quoted numbers to numbers, and the booleans
+// to 0 or 1
+func coerceNumber(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" {
+			return nil
+		}
+		if _, err := strconv.ParseFloat(s, 64); err == nil {
+			return json.Number(s)
+		}
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	}
+
+	return value
+}
+
+// jsonCandidate is a field of a struct type a JSON key may be decoded into
+type jsonCandidate struct {
+	name   string
+	tagged bool
+	depth  int
+	field  reflect.StructField
+}
+
+// jsonField returns the field of the struct type t the JSON key is decoded
+// into, following the matching rules of encoding/json
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	fields := jsonFields(t)
+
+	for _, f := range fields {
+		if f.name == key {
+			return f.field, true
+		}
+	}
+
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f.field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// jsonFields returns the fields of the struct type t decoded from JSON,
+// including the ones promoted from its embedded structs. As with
+// encoding/json, a shallower field hides the deeper ones of the same name,
+// and the fields of the same name and depth hide each other unless only one
+// of them is tagged.
+func jsonFields(t reflect.Type) []jsonCandidate {
+	var candidates []jsonCandidate
+	visited := make(map[reflect.Type]bool)
+
+	level := []reflect.Type{t}
+	for depth := 0; len(level) > 0; depth++ {
+		var next []reflect.Type
+
+		for _, st := range level {
+			if visited[st] {
+				continue
+			}
+			visited[st] = true
+
+			for i := 0; i < st.NumField(); i++ {
+				field := st.Field(i)
+
+				ft := field.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+
+				// The exported fields of embedded unexported structs are
+				// still promoted
+				if field.Anonymous {
+					if field.PkgPath != "" && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if field.PkgPath != "" {
+					continue
+				}
+
+				tag := field.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+
+				name := strings.Split(tag, ",")[0]
+				if name == "" && field.Anonymous && ft.Kind() == reflect.Struct {
+					next = append(next, ft)
+					continue
+				}
+
+				candidate := jsonCandidate{name: name, tagged: name != "", depth: depth, field: field}
+				if name == "" {
+					candidate.name = field.Name
+				}
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		level = next
+	}
+
+	byName := make(map[string][]jsonCandidate)
+	for _, c := range candidates {
+		byName[c.name] = append(byName[c.name], c)
+	}
+
+	var fields []jsonCandidate
+	for _, c := range candidates {
+		group, ok := byName[c.name]
+		if !ok {
+			continue
+		}
+		delete(byName, c.name)
+
+		if dominant, ok := dominantField(group); ok {
+			fields = append(fields, dominant)
+		}
+	}
+
+	return fields
+}
+
+// dominantField returns the field hiding the others of the same name, if
+// any. The fields are sorted by depth.
+func dominantField(fields []jsonCandidate) (jsonCandidate, bool) {
+	var dominant []jsonCandidate
+	for _, f := range fields {
+		if f.depth != fields[0].depth {
+			break
+		}
+		if f.tagged {
+			dominant = append(dominant, f)
+		}
+	}
+
+	if len(dominant) == 0 {
+		for _, f := range fields {
+			if f.depth == fields[0].depth {
+				dominant = append(dominant, f)
+			}
+		}
+	}
+
+	if len(dominant) > 1 {
+		return jsonCandidate{}, false
+	}
+
+	return dominant[0], true
+}
@@ -0,0 +1,160 @@
+package fanfou
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const inconsistentStatusJSON = `{"id": "test_id", "truncated": "false", "favorited": 1, "rawid": "42", "user": {"id": "test_user_id", "protected": "true", "followers_count": "12", "utc_offset": ""}}`
+
+func TestDo_decodeErrorDetails(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprint(w, inconsistentStatusJSON)
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "statuses/show.json", "")
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	resp, err := client.Do(req, new(StatusResult))
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("Do returned %v, want DecodeError", err)
+	}
+
+	if decodeErr.Field != "truncated" {
+		t.Errorf("DecodeError.Field = %v, want %v", decodeErr.Field, "truncated")
+	}
+
+	if decodeErr.Body != inconsistentStatusJSON {
+		t.Errorf("DecodeError.Body = %v, want %v", decodeErr.Body, inconsistentStatusJSON)
+	}
+
+	if resp == nil || resp.BodyStrPtr == nil || *resp.BodyStrPtr != inconsistentStatusJSON {
+		t.Errorf("Do returned response %+v, want the raw body", resp)
+	}
+}
+
+func TestStatusesService_ShowDecodeErrorBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprint(w, inconsistentStatusJSON)
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	_, body, err := client.Statuses.Show("test_id", nil)
+	if !errors.As(err, new(*DecodeError)) {
+		t.Fatalf("statuses.show returned %v, want DecodeError", err)
+	}
+
+	if body == nil || *body != inconsistentStatusJSON {
+		t.Errorf("statuses.show returned body %v, want the raw body", body)
+	}
+}
+
+func TestDo_lenientDecoding(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprint(w, inconsistentStatusJSON)
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	c := newTestClient("test", "test", WithLenientDecoding(true))
+	if err := c.AuthorizeClientWithAccessTokens("test", "test", nil); err != nil {
+		t.Fatalf("AuthorizeClientWithAccessTokens() returned error: %v", err)
+	}
+
+	status, _, err := c.Statuses.Show("test_id", nil)
+	if err != nil {
+		t.Fatalf("statuses.show returned error: %v", err)
+	}
+
+	want := &StatusResult{
+		ID:        "test_id",
+		Favorited: true,
+		Rawid:     42,
+	}
+	want.User = &UserResult{
+		ID:             "test_user_id",
+		Protected:      true,
+		FollowersCount: 12,
+	}
+
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("statuses.show returned %+v, want %+v", status, want)
+	}
+}
+
+type embeddedCounts struct {
+	Count int64  `json:"count"`
+	Name  int64  `json:"name"`
+	Flag  string `json:"flag"`
+}
+
+type embeddedFlags struct {
+	Verified bool `json:"verified"`
+}
+
+type embeddingResult struct {
+	embeddedCounts
+	embeddedFlags
+
+	Name string `json:"name"`
+	Flag bool
+}
+
+func TestDecodeJSON_embedded(t *testing.T) {
+	data := []byte(`{"count": "3", "name": 7, "verified": "true", "flag": 1}`)
+
+	var strict embeddingResult
+	err := decodeJSON(data, &strict, false)
+
+	var decodeErr *DecodeError
+	if !errors.As(newDecodeError("test", data, err), &decodeErr) || decodeErr.Field != "count" {
+		t.Errorf("decodeJSON returned %v, want a DecodeError of field %v", err, "count")
+	}
+
+	var got embeddingResult
+	if err := decodeJSON(data, &got, true); err != nil {
+		t.Fatalf("decodeJSON returned error: %v", err)
+	}
+
+	want := embeddingResult{
+		embeddedCounts: embeddedCounts{Count: 3, Flag: "1"},
+		embeddedFlags:  embeddedFlags{Verified: true},
+		Name:           "7",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decodeJSON returned %+v, want %+v", got, want)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	body := []byte(strings.Repeat("饭", maxSnippetLen))
+
+	got := snippet(body, len(body)/2)
+	if len(got) > maxSnippetLen || !strings.HasPrefix(got, "饭") || !strings.HasSuffix(got, "饭") {
+		t.Errorf("snippet returned %q, want at most %d bytes of whole characters", got, maxSnippetLen)
+	}
+}
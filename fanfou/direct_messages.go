@@ -1,6 +1,7 @@
 package fanfou
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -21,7 +22,7 @@ type DirectMessageResult struct {
 	Text                string               `json:"text,omitempty"`
 	SenderID            string               `json:"sender_id,omitempty"`
 	RecipientID         string               `json:"recipient_id,omitempty"`
-	CreatedAt           string               `json:"created_at,omitempty"`
+	CreatedAt           *Timestamp           `json:"created_at,omitempty"`
 	SenderScreenName    string               `json:"sender_screen_name,omitempty"`
 	RecipientScreenName string               `json:"recipient_screen_name,omitempty"`
 	Sender              *UserResult          `json:"sender,omitempty"`
@@ -29,6 +30,25 @@ type DirectMessageResult struct {
 	InReplyTo           *DirectMessageResult `json:"in_reply_to,omitempty"`
 }
 
+// GetSender returns the sender of the message, or nil if it is missing
+func (m *DirectMessageResult) GetSender() *UserResult {
+	if m == nil {
+		return nil
+	}
+
+	return m.Sender
+}
+
+// GetRecipient returns the recipient of the message, or nil if it is
+// missing
+func (m *DirectMessageResult) GetRecipient() *UserResult {
+	if m == nil {
+		return nil
+	}
+
+	return m.Recipient
+}
+
 // DirectMessageConversationListResult specifies Fanfou's direct messages conversation list data structure
 type DirectMessageConversationListResult []DirectMessageConversationListItem
 
@@ -59,6 +79,12 @@ type DirectMessagesOptParams struct {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/direct-messages.conversation
 func (s *DirectMessagesService) Conversation(ID string, opt *DirectMessagesOptParams) ([]DirectMessageResult, *string, error) {
+	return s.ConversationContext(context.Background(), ID, opt)
+}
+
+// ConversationContext is the same as Conversation, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *DirectMessagesService) ConversationContext(ctx context.Context, ID string, opt *DirectMessagesOptParams) ([]DirectMessageResult, *string, error) {
 	u := fmt.Sprintf("direct_messages/conversation.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -84,7 +110,7 @@ func (s *DirectMessagesService) Conversation(ID string, opt *DirectMessagesOptPa
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -92,7 +118,7 @@ func (s *DirectMessagesService) Conversation(ID string, opt *DirectMessagesOptPa
 	newDirectMessages := new([]DirectMessageResult)
 	resp, err := s.client.Do(req, newDirectMessages)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newDirectMessages, resp.BodyStrPtr, nil
@@ -102,6 +128,12 @@ func (s *DirectMessagesService) Conversation(ID string, opt *DirectMessagesOptPa
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/direct-messages.new
 func (s *DirectMessagesService) New(user, text string, opt *DirectMessagesOptParams) (*DirectMessageResult, *string, error) {
+	return s.NewContext(context.Background(), user, text, opt)
+}
+
+// NewContext is the same as New, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *DirectMessagesService) NewContext(ctx context.Context, user, text string, opt *DirectMessagesOptParams) (*DirectMessageResult, *string, error) {
 	u := fmt.Sprintf("direct_messages/new.json")
 	params := url.Values{
 		"user": []string{user},
@@ -117,7 +149,7 @@ func (s *DirectMessagesService) New(user, text string, opt *DirectMessagesOptPar
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -125,7 +157,7 @@ func (s *DirectMessagesService) New(user, text string, opt *DirectMessagesOptPar
 	newDirectMessage := new(DirectMessageResult)
 	resp, err := s.client.Do(req, newDirectMessage)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newDirectMessage, resp.BodyStrPtr, nil
@@ -137,12 +169,18 @@ func (s *DirectMessagesService) New(user, text string, opt *DirectMessagesOptPar
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/direct-messages.destroy
 func (s *DirectMessagesService) Destroy(ID string) (*DirectMessageResult, *string, error) {
+	return s.DestroyContext(context.Background(), ID)
+}
+
+// DestroyContext is the same as Destroy, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *DirectMessagesService) DestroyContext(ctx context.Context, ID string) (*DirectMessageResult, *string, error) {
 	u := fmt.Sprintf("direct_messages/destroy.json")
 	params := url.Values{
 		"id": []string{ID},
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -150,7 +188,7 @@ func (s *DirectMessagesService) Destroy(ID string) (*DirectMessageResult, *strin
 	newDirectMessage := new(DirectMessageResult)
 	resp, err := s.client.Do(req, newDirectMessage)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newDirectMessage, resp.BodyStrPtr, nil
@@ -161,6 +199,12 @@ func (s *DirectMessagesService) Destroy(ID string) (*DirectMessageResult, *strin
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/direct-messages.conversation-list
 func (s *DirectMessagesService) ConversationList(opt *DirectMessagesOptParams) (*DirectMessageConversationListResult, *string, error) {
+	return s.ConversationListContext(context.Background(), opt)
+}
+
+// ConversationListContext is the same as ConversationList, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *DirectMessagesService) ConversationListContext(ctx context.Context, opt *DirectMessagesOptParams) (*DirectMessageConversationListResult, *string, error) {
 	u := fmt.Sprintf("direct_messages/conversation_list.json")
 	params := url.Values{}
 
@@ -178,7 +222,7 @@ func (s *DirectMessagesService) ConversationList(opt *DirectMessagesOptParams) (
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -186,7 +230,7 @@ func (s *DirectMessagesService) ConversationList(opt *DirectMessagesOptParams) (
 	newDirectMessages := new(DirectMessageConversationListResult)
 	resp, err := s.client.Do(req, newDirectMessages)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newDirectMessages, resp.BodyStrPtr, nil
@@ -196,6 +240,12 @@ func (s *DirectMessagesService) ConversationList(opt *DirectMessagesOptParams) (
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/direct-messages.inbox
 func (s *DirectMessagesService) Inbox(opt *DirectMessagesOptParams) ([]DirectMessageResult, *string, error) {
+	return s.InboxContext(context.Background(), opt)
+}
+
+// InboxContext is the same as Inbox, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *DirectMessagesService) InboxContext(ctx context.Context, opt *DirectMessagesOptParams) ([]DirectMessageResult, *string, error) {
 	u := fmt.Sprintf("direct_messages/inbox.json")
 	params := url.Values{}
 
@@ -219,7 +269,7 @@ func (s *DirectMessagesService) Inbox(opt *DirectMessagesOptParams) ([]DirectMes
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -227,7 +277,7 @@ func (s *DirectMessagesService) Inbox(opt *DirectMessagesOptParams) ([]DirectMes
 	newDirectMessages := new([]DirectMessageResult)
 	resp, err := s.client.Do(req, newDirectMessages)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newDirectMessages, resp.BodyStrPtr, nil
@@ -237,6 +287,12 @@ func (s *DirectMessagesService) Inbox(opt *DirectMessagesOptParams) ([]DirectMes
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/direct-messages.sent
 func (s *DirectMessagesService) Sent(opt *DirectMessagesOptParams) ([]DirectMessageResult, *string, error) {
+	return s.SentContext(context.Background(), opt)
+}
+
+// SentContext is the same as Sent, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *DirectMessagesService) SentContext(ctx context.Context, opt *DirectMessagesOptParams) ([]DirectMessageResult, *string, error) {
 	u := fmt.Sprintf("direct_messages/sent.json")
 	params := url.Values{}
 
@@ -260,7 +316,7 @@ func (s *DirectMessagesService) Sent(opt *DirectMessagesOptParams) ([]DirectMess
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -268,7 +324,7 @@ func (s *DirectMessagesService) Sent(opt *DirectMessagesOptParams) ([]DirectMess
 	newDirectMessages := new([]DirectMessageResult)
 	resp, err := s.client.Do(req, newDirectMessages)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newDirectMessages, resp.BodyStrPtr, nil
@@ -37,7 +37,7 @@ func TestDirectMessagesService_Conversation(t *testing.T) {
 			Text:                "test_text",
 			SenderID:            "test_sender_id",
 			RecipientID:         "test_recipient_id",
-			CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+			CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 			SenderScreenName:    "test_sender_screen_name",
 			RecipientScreenName: "test_recipient_screen_name",
 			Sender: &UserResult{
@@ -51,7 +51,7 @@ func TestDirectMessagesService_Conversation(t *testing.T) {
 				Text:                "test_text",
 				SenderID:            "test_sender_id",
 				RecipientID:         "test_recipient_id",
-				CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+				CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 				SenderScreenName:    "test_sender_screen_name",
 				RecipientScreenName: "test_recipient_screen_name",
 				Sender: &UserResult{
@@ -67,7 +67,7 @@ func TestDirectMessagesService_Conversation(t *testing.T) {
 			Text:                "test_text",
 			SenderID:            "test_sender_id",
 			RecipientID:         "test_recipient_id",
-			CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+			CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 			SenderScreenName:    "test_sender_screen_name",
 			RecipientScreenName: "test_recipient_screen_name",
 			Sender: &UserResult{
@@ -81,7 +81,7 @@ func TestDirectMessagesService_Conversation(t *testing.T) {
 				Text:                "test_text",
 				SenderID:            "test_sender_id",
 				RecipientID:         "test_recipient_id",
-				CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+				CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 				SenderScreenName:    "test_sender_screen_name",
 				RecipientScreenName: "test_recipient_screen_name",
 				Sender: &UserResult{
@@ -124,7 +124,7 @@ func TestDirectMessagesService_New(t *testing.T) {
 		Text:                "test_text",
 		SenderID:            "test_sender_id",
 		RecipientID:         "test_recipient_id",
-		CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+		CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 		SenderScreenName:    "test_sender_screen_name",
 		RecipientScreenName: "test_recipient_screen_name",
 		Sender: &UserResult{
@@ -138,7 +138,7 @@ func TestDirectMessagesService_New(t *testing.T) {
 			Text:                "test_text",
 			SenderID:            "test_sender_id",
 			RecipientID:         "test_recipient_id",
-			CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+			CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 			SenderScreenName:    "test_sender_screen_name",
 			RecipientScreenName: "test_recipient_screen_name",
 			Sender: &UserResult{
@@ -177,7 +177,7 @@ func TestDirectMessagesService_Destroy(t *testing.T) {
 		Text:                "test_text",
 		SenderID:            "test_sender_id",
 		RecipientID:         "test_recipient_id",
-		CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+		CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 		SenderScreenName:    "test_sender_screen_name",
 		RecipientScreenName: "test_recipient_screen_name",
 		Sender: &UserResult{
@@ -191,7 +191,7 @@ func TestDirectMessagesService_Destroy(t *testing.T) {
 			Text:                "test_text",
 			SenderID:            "test_sender_id",
 			RecipientID:         "test_recipient_id",
-			CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+			CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 			SenderScreenName:    "test_sender_screen_name",
 			RecipientScreenName: "test_recipient_screen_name",
 			Sender: &UserResult{
@@ -236,7 +236,7 @@ func TestDirectMessagesService_ConversationList(t *testing.T) {
 				Text:                "test_text",
 				SenderID:            "test_sender_id",
 				RecipientID:         "test_recipient_id",
-				CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+				CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 				SenderScreenName:    "test_sender_screen_name",
 				RecipientScreenName: "test_recipient_screen_name",
 				Sender: &UserResult{
@@ -257,7 +257,7 @@ func TestDirectMessagesService_ConversationList(t *testing.T) {
 				Text:                "test_text",
 				SenderID:            "test_sender_id",
 				RecipientID:         "test_recipient_id",
-				CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+				CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 				SenderScreenName:    "test_sender_screen_name",
 				RecipientScreenName: "test_recipient_screen_name",
 				Sender: &UserResult{
@@ -308,7 +308,7 @@ func TestDirectMessagesService_Inbox(t *testing.T) {
 			Text:                "test_text",
 			SenderID:            "test_sender_id",
 			RecipientID:         "test_recipient_id",
-			CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+			CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 			SenderScreenName:    "test_sender_screen_name",
 			RecipientScreenName: "test_recipient_screen_name",
 			Sender: &UserResult{
@@ -322,7 +322,7 @@ func TestDirectMessagesService_Inbox(t *testing.T) {
 				Text:                "test_text",
 				SenderID:            "test_sender_id",
 				RecipientID:         "test_recipient_id",
-				CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+				CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 				SenderScreenName:    "test_sender_screen_name",
 				RecipientScreenName: "test_recipient_screen_name",
 				Sender: &UserResult{
@@ -338,7 +338,7 @@ func TestDirectMessagesService_Inbox(t *testing.T) {
 			Text:                "test_text",
 			SenderID:            "test_sender_id",
 			RecipientID:         "test_recipient_id",
-			CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+			CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 			SenderScreenName:    "test_sender_screen_name",
 			RecipientScreenName: "test_recipient_screen_name",
 			Sender: &UserResult{
@@ -352,7 +352,7 @@ func TestDirectMessagesService_Inbox(t *testing.T) {
 				Text:                "test_text",
 				SenderID:            "test_sender_id",
 				RecipientID:         "test_recipient_id",
-				CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+				CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 				SenderScreenName:    "test_sender_screen_name",
 				RecipientScreenName: "test_recipient_screen_name",
 				Sender: &UserResult{
@@ -399,7 +399,7 @@ func TestDirectMessagesService_Sent(t *testing.T) {
 			Text:                "test_text",
 			SenderID:            "test_sender_id",
 			RecipientID:         "test_recipient_id",
-			CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+			CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 			SenderScreenName:    "test_sender_screen_name",
 			RecipientScreenName: "test_recipient_screen_name",
 			Sender: &UserResult{
@@ -413,7 +413,7 @@ func TestDirectMessagesService_Sent(t *testing.T) {
 				Text:                "test_text",
 				SenderID:            "test_sender_id",
 				RecipientID:         "test_recipient_id",
-				CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+				CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 				SenderScreenName:    "test_sender_screen_name",
 				RecipientScreenName: "test_recipient_screen_name",
 				Sender: &UserResult{
@@ -429,7 +429,7 @@ func TestDirectMessagesService_Sent(t *testing.T) {
 			Text:                "test_text",
 			SenderID:            "test_sender_id",
 			RecipientID:         "test_recipient_id",
-			CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+			CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 			SenderScreenName:    "test_sender_screen_name",
 			RecipientScreenName: "test_recipient_screen_name",
 			Sender: &UserResult{
@@ -443,7 +443,7 @@ func TestDirectMessagesService_Sent(t *testing.T) {
 				Text:                "test_text",
 				SenderID:            "test_sender_id",
 				RecipientID:         "test_recipient_id",
-				CreatedAt:           "Thu Nov 17 03:45:20 +0000 2011",
+				CreatedAt:           mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011"),
 				SenderScreenName:    "test_sender_screen_name",
 				RecipientScreenName: "test_recipient_screen_name",
 				Sender: &UserResult{
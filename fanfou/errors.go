@@ -0,0 +1,115 @@
+package fanfou
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors the API errors can be matched against with errors.Is, e.g.
+//
+//	_, _, err := c.Statuses.Show(id, nil)
+//	if errors.Is(err, fanfou.ErrNotFound) {
+//		// the status has been deleted
+//	}
+var (
+	// ErrNotFound is matched by the errors of the requests to missing
+	// statuses, users or messages
+	ErrNotFound = errors.New("fanfou: not found")
+
+	// ErrUnauthorized is matched by the errors of the requests whose
+	// credentials are rejected, e.g. a revoked access token
+	ErrUnauthorized = errors.New("fanfou: unauthorized")
+
+	// ErrRateLimited is matched by the errors of the throttled requests,
+	// see RateLimitError
+	ErrRateLimited = errors.New("fanfou: rate limited")
+
+	// ErrProtectedUser is matched by the errors of the requests to the
+	// content of a protected user who is not followed
+	ErrProtectedUser = errors.New("fanfou: protected user")
+
+	// ErrDuplicateStatus is matched by the errors of the statuses rejected
+	// for being posted twice
+	ErrDuplicateStatus = errors.New("fanfou: duplicate status")
+
+	// ErrNotAuthorized is returned by the requests of a client without an
+	// access token, which are not sent
+	ErrNotAuthorized = errors.New("fanfou: client is not authorized")
+)
+
+// protectedUserMarkers are the fragments of the error messages Fanfou
+// returns when the content of a protected user is requested
+var protectedUserMarkers = []string{
+	"protected",
+	"保护",
+	"没有通过",
+}
+
+// duplicateStatusMarkers are the fragments of the error messages Fanfou
+// returns when a status is posted twice
+var duplicateStatusMarkers = []string{
+	"duplicate",
+	"重复",
+}
+
+// classify returns the sentinel error matching the API error, or nil if
+// there is none
+func classify(r *ErrorResponse) error {
+	if r.Response == nil {
+		return nil
+	}
+
+	msg := ""
+	if r.Meta != nil {
+		msg = strings.ToLower(r.Meta.Error)
+	}
+
+	switch {
+	case r.Response.StatusCode == http.StatusTooManyRequests || isRateLimited(r):
+		return ErrRateLimited
+	case r.Response.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case r.Response.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case r.Response.StatusCode == http.StatusForbidden && containsAny(msg, protectedUserMarkers):
+		return ErrProtectedUser
+	case containsAny(msg, duplicateStatusMarkers):
+		return ErrDuplicateStatus
+	}
+
+	return nil
+}
+
+// containsAny reports whether s contains any of the fragments
+func containsAny(s string, fragments []string) bool {
+	for _, fragment := range fragments {
+		if strings.Contains(s, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AuthError is returned when an authorization request fails without a
+// response from the API, e.g. because of a network error or a malformed
+// token. Errors of the API during authorization are returned as
+// *ErrorResponse.
+type AuthError struct {
+	// Op is the authorization step which failed, e.g. "AuthorizeClient"
+	Op string
+
+	// Err is the underlying error
+	Err error
+}
+
+// Error implements the error interface
+func (e *AuthError) Error() string {
+	return e.Op + " error: " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,106 @@
+package fanfou
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestErrorResponse_classification(t *testing.T) {
+	tests := []struct {
+		status  int
+		message string
+		want    error
+	}{
+		{http.StatusNotFound, "没有这条消息", ErrNotFound},
+		{http.StatusUnauthorized, "Invalid signature", ErrUnauthorized},
+		{http.StatusForbidden, "API 请求频率超出限制", ErrRateLimited},
+		{http.StatusTooManyRequests, "", ErrRateLimited},
+		{http.StatusForbidden, "你没有通过这个用户的验证", ErrProtectedUser},
+		{http.StatusBadRequest, "不能重复发送相同内容", ErrDuplicateStatus},
+		{http.StatusBadRequest, "test_error", nil},
+	}
+
+	for _, tt := range tests {
+		setup()
+
+		mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			_, err := fmt.Fprintf(w, `{"request": "/statuses/show.json", "error": %q}`, tt.message)
+			if err != nil {
+				t.Errorf("statuses.show mock server error: %+v", err)
+			}
+		})
+
+		_, _, err := client.Statuses.Show("test_id", nil)
+
+		var fanfouErr *ErrorResponse
+		if !errors.As(err, &fanfouErr) {
+			t.Errorf("statuses.show returned %v, want ErrorResponse", err)
+		} else if fanfouErr.StatusCode() != tt.status {
+			t.Errorf("ErrorResponse.StatusCode() = %v, want %v", fanfouErr.StatusCode(), tt.status)
+		}
+
+		for _, sentinel := range []error{ErrNotFound, ErrUnauthorized, ErrRateLimited, ErrProtectedUser, ErrDuplicateStatus} {
+			if got, want := errors.Is(err, sentinel), sentinel == tt.want; got != want {
+				t.Errorf("errors.Is(%v, %v) = %v, want %v", err, sentinel, got, want)
+			}
+		}
+
+		teardown()
+	}
+}
+
+func TestRateLimitError_is(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &RateLimitError{})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("errors.Is(%v, ErrRateLimited) = false, want true", err)
+	}
+}
+
+func TestCheckAuthResponse_authError(t *testing.T) {
+	cause := errors.New("test_error")
+
+	err := CheckAuthResponse(cause, "test_tag")
+
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("CheckAuthResponse() returned %v, want AuthError", err)
+	}
+
+	if authErr.Op != "test_tag" {
+		t.Errorf("AuthError.Op = %v, want %v", authErr.Op, "test_tag")
+	}
+
+	if !errors.Is(err, cause) {
+		t.Errorf("errors.Is(%v, %v) = false, want true", err, cause)
+	}
+
+	if want := "test_tag error: test_error"; err.Error() != want {
+		t.Errorf("AuthError.Error() = %v, want %v", err.Error(), want)
+	}
+}
+
+func TestDo_decodeError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	_, _, err := client.Statuses.Show("test_id", nil)
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("statuses.show returned %v, want DecodeError", err)
+	}
+
+	if decodeErr.Endpoint != "statuses/show" {
+		t.Errorf("DecodeError.Endpoint = %v, want %v", decodeErr.Endpoint, "statuses/show")
+	}
+}
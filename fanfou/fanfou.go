@@ -2,15 +2,19 @@ package fanfou
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"strings"
+	"sync"
 
 	"github.com/mogita/oauth"
 )
@@ -39,6 +43,9 @@ var (
 )
 
 // A Client manages communication with the Fanfou API.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its exported
+// fields shall not be modified once the client is in use.
 type Client struct {
 	// HTTP client used to communicate with the API.
 	client *http.Client
@@ -46,6 +53,27 @@ type Client struct {
 	// OAuth consumer used to handle authentication work
 	oauthConsumer *oauth.Consumer
 
+	// HTTP client the OAuth consumer sends the signed requests with
+	baseClient *http.Client
+
+	// Base URL for authorization requests.
+	authBaseURL *url.URL
+
+	// Whether the traffic is traced, to stderr unless a logger is set
+	debug bool
+
+	// Logger the traffic is traced to, see Logger
+	logger Logger
+
+	// Whether mismatching types are tolerated when decoding the responses
+	lenient bool
+
+	// Format of the endpoints the client talks to, JSON if empty
+	wireFormat WireFormat
+
+	// Middlewares hooked into the traffic, see Middleware
+	middlewares []Middleware
+
 	// Base URL for API requests.
 	BaseURL *url.URL
 
@@ -58,6 +86,22 @@ type Client struct {
 	// Application consumer secret
 	ConsumerSecret string
 
+	// Retry policy for requests failing with a transient error.
+	// Requests are not retried if nil.
+	RetryPolicy *RetryPolicy
+
+	// Rate limiter keeping track of the hourly budget of API calls.
+	// Requests are not limited if nil.
+	RateLimiter *RateLimiter
+
+	// Collector of the measurements of the requests, see MetricsCollector.
+	// Nothing is measured if nil.
+	Metrics MetricsCollector
+
+	// Tracer starting a span around every request, see Tracer.
+	// Nothing is traced if nil.
+	Tracer Tracer
+
 	// Services used for talking to different parts of the API.
 	Users          *UsersService
 	Statuses       *StatusesService
@@ -73,8 +117,12 @@ type Client struct {
 	Friendships    *FriendshipsService
 	DirectMessages *DirectMessagesService
 
-	// Temporary Response
-	Response *Response
+	// Access token the client is authorized with
+	accessToken *oauth.AccessToken
+
+	// mu guards client and accessToken, which are swapped whenever the
+	// client is authorized again
+	mu sync.RWMutex
 }
 
 // RequestToken provides the structure as oauth.RequestToken
@@ -91,25 +139,47 @@ type AccessToken struct {
 }
 
 // NewClient returns a new Fanfou API client.
-func NewClient(consumerKey, consumerSecret string) *Client {
+//
+// The client talks to the official Fanfou API with a default HTTP client,
+// which can be changed with options, e.g.
+//
+//	c := fanfou.NewClient(consumerKey, consumerSecret,
+//		fanfou.WithHTTPClient(&http.Client{Timeout: 10 * time.Second}),
+//		fanfou.WithUserAgent("my-app/1.0"),
+//	)
+func NewClient(consumerKey, consumerSecret string, opts ...Option) *Client {
 	baseURL, _ := url.Parse(BaseURL)
+	authBaseURL, _ := url.Parse(AuthBaseURL)
 
 	c := &Client{
 		BaseURL:        baseURL,
 		UserAgent:      UserAgent,
 		ConsumerKey:    consumerKey,
 		ConsumerSecret: consumerSecret,
-		oauthConsumer: oauth.NewConsumer(
-			consumerKey,
-			consumerSecret,
-			oauth.ServiceProvider{
-				RequestTokenUrl:   AuthBaseURL + requestTokenURI,
-				AuthorizeTokenUrl: AuthBaseURL + authorizeTokenURI,
-				AccessTokenUrl:    AuthBaseURL + accessTokenURI,
-			},
-		),
+		authBaseURL:    authBaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.debug && c.logger == nil {
+		c.logger = debugLogger()
 	}
 
+	c.oauthConsumer = oauth.NewCustomHttpClientConsumer(
+		consumerKey,
+		consumerSecret,
+		oauth.ServiceProvider{
+			RequestTokenUrl:   c.authURL(requestTokenURI),
+			AuthorizeTokenUrl: c.authURL(authorizeTokenURI),
+			AccessTokenUrl:    c.authURL(accessTokenURI),
+		},
+		c.baseClient,
+	)
+
+	// The consumer prints its own traces to stdout, secrets included, so
+	// the traffic is traced by the client instead
 	c.oauthConsumer.Debug(false)
 
 	c.Users = &UsersService{client: c}
@@ -129,6 +199,11 @@ func NewClient(consumerKey, consumerSecret string) *Client {
 	return c
 }
 
+// authURL resolves the authorization URI relative to the auth base URL
+func (c *Client) authURL(uri string) string {
+	return c.authBaseURL.ResolveReference(&url.URL{Path: uri}).String()
+}
+
 // GetRequestTokenAndURL returns the request token and the login url for authorizing this token.
 //
 // "callbackURL" can be "oob" if you're running your application outside a browser.
@@ -136,8 +211,17 @@ func NewClient(consumerKey, consumerSecret string) *Client {
 // Read more about "oob" authorization at:
 // https://github.com/mogita/FanFouAPIDoc/wiki/Oauth#%E4%BD%BF%E7%94%A8pin%E7%A0%81%E8%8E%B7%E5%BE%97%E6%8E%88%E6%9D%83
 func (c *Client) GetRequestTokenAndURL(callbackURL string) (*RequestToken, string, error) {
-	rToken, loginURL, err := c.oauthConsumer.GetRequestTokenAndUrl(callbackURL)
+	return c.GetRequestTokenAndURLContext(context.Background(), callbackURL)
+}
+
+// GetRequestTokenAndURLContext is the same as GetRequestTokenAndURL, except
+// that the token request is bound to ctx and aborted once ctx is done
+func (c *Client) GetRequestTokenAndURLContext(ctx context.Context, callbackURL string) (*RequestToken, string, error) {
+	rToken, loginURL, err := c.contextConsumer(ctx).GetRequestTokenAndUrl(callbackURL)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, "", ctx.Err()
+		}
 		return nil, "", CheckAuthResponse(err, "GetRequestTokenAndURL")
 	}
 
@@ -158,56 +242,80 @@ func (c *Client) GetRequestTokenAndURL(callbackURL string) (*RequestToken, strin
 //
 // If you use "oob" mode, you also need to provide the verificationCode
 func (c *Client) AuthorizeClient(requestToken *RequestToken, verificationCode string) (*AccessToken, error) {
+	return c.AuthorizeClientContext(context.Background(), requestToken, verificationCode)
+}
+
+// AuthorizeClientContext is the same as AuthorizeClient, except that the
+// access token request is bound to ctx and aborted once ctx is done
+func (c *Client) AuthorizeClientContext(ctx context.Context, requestToken *RequestToken, verificationCode string) (*AccessToken, error) {
 	rToken := oauth.RequestToken{
 		Token:  requestToken.Token,
 		Secret: requestToken.Secret,
 	}
 
-	accessToken, err := c.oauthConsumer.AuthorizeToken(&rToken, verificationCode)
+	accessToken, err := c.contextConsumer(ctx).AuthorizeToken(&rToken, verificationCode)
 
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		return nil, CheckAuthResponse(err, "AuthorizeClient")
 	}
 
-	c.client, err = c.oauthConsumer.MakeHttpClient(accessToken)
-
+	err = c.authorize(accessToken)
 	if err != nil {
 		return nil, err
 	}
 
-	newAccessToken := AccessToken{
-		Token:          accessToken.Token,
-		Secret:         accessToken.Secret,
-		AdditionalData: accessToken.AdditionalData,
-	}
-
-	return &newAccessToken, nil
+	return newAccessToken(accessToken), nil
 }
 
 // AuthorizeClientWithXAuth completes the OAuth authorization to the client
-// with XAuth so it can make requests to protected contents
+// with XAuth so it can make requests to protected contents, and returns the
+// access token so it can be stored instead of the credentials
 //
 // This method is a simplified OAuth process, taking username and password
-// to authorize the client, without the need to redirect to the web UI
-func (c *Client) AuthorizeClientWithXAuth(username, password string) error {
-	c.oauthConsumer.AdditionalParams["x_auth_username"] = username
-	c.oauthConsumer.AdditionalParams["x_auth_password"] = password
-	c.oauthConsumer.AdditionalParams["x_auth_mode"] = "client_auth"
+// to authorize the client, without the need to redirect to the web UI.
+// The credentials are only sent with the access token request, and the
+// client keeps no reference to them afterwards.
+func (c *Client) AuthorizeClientWithXAuth(username, password string) (*AccessToken, error) {
+	return c.AuthorizeClientWithXAuthContext(context.Background(), username, password)
+}
+
+// AuthorizeClientWithXAuthContext is the same as AuthorizeClientWithXAuth,
+// except that the access token request is bound to ctx and aborted once
+// ctx is done
+func (c *Client) AuthorizeClientWithXAuthContext(ctx context.Context, username, password string) (*AccessToken, error) {
+	// The credentials are only added to the params of this very request,
+	// the consumer shared by all requests is left untouched
+	params := make(map[string]string, len(c.oauthConsumer.AdditionalParams)+3)
+	for key, val := range c.oauthConsumer.AdditionalParams {
+		params[key] = val
+	}
+	params["x_auth_username"] = username
+	params["x_auth_password"] = password
+	params["x_auth_mode"] = "client_auth"
 
 	reqToken := oauth.RequestToken{}
-	accessToken, err := c.oauthConsumer.AuthorizeToken(&reqToken, "")
+	accessToken, err := c.contextConsumer(ctx).AuthorizeTokenWithParams(&reqToken, "", params)
+
+	// Go strings cannot be wiped in place: the params holding the
+	// credentials are cleared instead, so that the client refers to them
+	// no more once the request is done
+	clear(params)
 
 	if err != nil {
-		return CheckAuthResponse(err, "AuthorizeClientWithXAuth")
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, CheckAuthResponse(err, "AuthorizeClientWithXAuth")
 	}
 
-	c.client, err = c.oauthConsumer.MakeHttpClient(accessToken)
-
-	if err != nil {
-		return err
+	if err := c.authorize(accessToken); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return newAccessToken(accessToken), nil
 }
 
 // AuthorizeClientWithAccessTokens completes the OAuth authorization to the client
@@ -220,29 +328,122 @@ func (c *Client) AuthorizeClientWithAccessTokens(accessToken, accessTokenSecret
 		AdditionalData: additionalData,
 	}
 
-	var err error
-	c.client, err = c.oauthConsumer.MakeHttpClient(&tokens)
+	return c.authorize(&tokens)
+}
+
+// newAccessToken returns the AccessToken of an oauth.AccessToken
+func newAccessToken(token *oauth.AccessToken) *AccessToken {
+	return &AccessToken{
+		Token:          token.Token,
+		Secret:         token.Secret,
+		AdditionalData: token.AdditionalData,
+	}
+}
 
+// authorize makes the client sign its requests with the given access token.
+// It can be called while other goroutines are sending requests.
+func (c *Client) authorize(token *oauth.AccessToken) error {
+	httpClient, err := c.oauthConsumer.MakeHttpClient(token)
 	if err != nil {
 		return err
 	}
 
+	c.mu.Lock()
+	c.client = httpClient
+	c.accessToken = token
+	c.mu.Unlock()
+
 	return nil
 }
 
+// httpClient returns the HTTP client of the latest authorization, or nil
+// if the client has not been authorized yet
+func (c *Client) httpClient() *http.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.client
+}
+
+// unsignedHTTPClient returns the HTTP client for requests sent outside of
+// the API, which are not signed
+func (c *Client) unsignedHTTPClient() *http.Client {
+	if c.baseClient != nil {
+		return c.baseClient
+	}
+
+	return http.DefaultClient
+}
+
+// tokenKey returns the access token the client is authorized with, or an
+// empty string if it has not been authorized yet
+func (c *Client) tokenKey() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.accessToken == nil {
+		return ""
+	}
+
+	return c.accessToken.Token
+}
+
+// contextConsumer returns a copy of the OAuth consumer whose token
+// requests are bound to ctx
+func (c *Client) contextConsumer(ctx context.Context) *oauth.Consumer {
+	consumer := *c.oauthConsumer
+	consumer.HttpClient = &contextHTTPClient{
+		ctx:    ctx,
+		client: c.oauthConsumer.HttpClient,
+		tracer: c,
+	}
+
+	return &consumer
+}
+
+// contextHTTPClient attaches a context to every request sent by the
+// OAuth consumer, which offers no way to pass one itself, and traces them
+type contextHTTPClient struct {
+	ctx    context.Context
+	client oauth.HttpClient
+	tracer *Client
+}
+
+// Do implements the oauth.HttpClient interface
+func (h *contextHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	req = req.WithContext(h.ctx)
+	h.tracer.traceRequest("fanfou auth request", req)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		h.tracer.logDebug(h.ctx, "fanfou auth request failed", "url", redactURL("", req.URL), "error", err)
+		return nil, err
+	}
+
+	h.tracer.logDebug(h.ctx, "fanfou auth response", "url", redactURL("", req.URL), "status", resp.StatusCode)
+
+	return resp, nil
+}
+
 // NewRequest creates an API request. A relative URL can be provided in uri,
 // in which case it is resolved relative to the BaseURL of the Client.
 //
 // Relative URLs should always be specified without a preceding slash.
 func (c *Client) NewRequest(method, uri string, body string) (*http.Request, error) {
-	rel, err := url.Parse(uri)
+	return c.NewRequestContext(context.Background(), method, uri, body)
+}
+
+// NewRequestContext is the same as NewRequest, except that the returned
+// request carries ctx, so sending it with Do is aborted once ctx is done
+func (c *Client) NewRequestContext(ctx context.Context, method, uri string, body string) (*http.Request, error) {
+	rel, err := url.Parse(c.formatURI(uri))
 	if err != nil {
 		return nil, err
 	}
 
 	u := c.BaseURL.ResolveReference(rel)
 
-	req, err := http.NewRequest(method, u.String(), bytes.NewBufferString(body))
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBufferString(body))
 	if err != nil {
 		return nil, err
 	}
@@ -262,7 +463,17 @@ func (c *Client) NewRequest(method, uri string, body string) (*http.Request, err
 //
 // Relative URLs should always be specified without a preceding slash.
 func (c *Client) NewUploadRequest(method, uri string, params map[string]string, fileParamName, filePath string) (*http.Request, error) {
-	rel, err := url.Parse(uri)
+	return c.NewUploadRequestContext(context.Background(), method, uri, params, fileParamName, filePath)
+}
+
+// NewUploadRequestContext is the same as NewUploadRequest, except that the
+// returned request carries ctx, so the upload is aborted once ctx is done
+func (c *Client) NewUploadRequestContext(ctx context.Context, method, uri string, params map[string]string, fileParamName, filePath string) (*http.Request, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rel, err := url.Parse(c.formatURI(uri))
 	if err != nil {
 		return nil, err
 	}
@@ -310,7 +521,7 @@ func (c *Client) NewUploadRequest(method, uri string, params map[string]string,
 		return nil, err
 	}
 
-	req, err := http.NewRequest(method, u.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -324,40 +535,141 @@ func (c *Client) NewUploadRequest(method, uri string, params map[string]string,
 // Do sends an API request and returns the API response. The API response is
 // decoded and stored in the value pointed to by v, or returned as an error if
 // an API error has occurred.
+//
+// The request is aborted once its context is done, in which case the
+// context's error is returned.
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
-	resp, err := c.client.Do(req)
+	return c.instrument(req, func(req *http.Request) (*Response, error) {
+		return c.do(req, v)
+	})
+}
+
+// do implements Do, without the measurements
+func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
+	ctx := req.Context()
+
+	httpClient := c.httpClient()
+	if httpClient == nil {
+		return nil, ErrNotAuthorized
+	}
+
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.acquire(ctx, c); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.send(httpClient, req)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		var r *ErrorResponse
+		if errors.As(err, &r) && isRateLimited(r) {
+			if c.RateLimiter != nil {
+				return nil, c.RateLimiter.exhaust(c, r)
+			}
+			return nil, &RateLimitError{Response: r}
+		}
+
 		return nil, err
 	}
+	if resp == nil {
+		return nil, errors.New("fanfou: no response")
+	}
 
 	defer func() {
 		err := resp.Body.Close()
 		if err != nil {
-			fmt.Printf("error closing body: %+v", err)
+			c.logWarn(ctx, "fanfou: error closing body", "error", err)
 		}
 	}()
 
-	err = CheckResponse(resp)
+	response := new(Response)
+	response.Response = resp
+
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	response := new(Response)
-
-	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+	if c.logger != nil {
+		endpoint := c.endpoint(req)
+		c.logDebug(ctx, "fanfou response body", "endpoint", endpoint, "body", redactBody(endpoint, bodyBytes))
+	}
 	tempStr := string(bodyBytes)
 	response.BodyStrPtr = &tempStr
 
 	if v != nil {
 		response.Data = v
-		err = json.Unmarshal(bodyBytes, response.Data)
-		c.Response = response
+		if err := c.decode(bodyBytes, response.Data); err != nil {
+			return response, newDecodeError(c.endpoint(req), bodyBytes, err)
+		}
+	}
+
+	return response, nil
+}
+
+// send sends the request until it succeeds or fails for good, according to
+// the retry policy of the client. The body of the returned response is left
+// open for the caller to read.
+func (c *Client) send(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	endpoint := c.endpoint(req)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.roundTrip(httpClient, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		if ctx.Err() != nil || !c.RetryPolicy.shouldRetry(attempt, endpoint, req, resp, err) {
+			return nil, err
+		}
+
+		wait := c.RetryPolicy.backoff(attempt, resp)
+		if c.RetryPolicy.OnRetry != nil {
+			c.RetryPolicy.OnRetry(RetryEvent{
+				Attempt:  attempt,
+				Request:  req,
+				Response: resp,
+				Err:      err,
+				Wait:     wait,
+			})
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+
+		req, err = rewindRequest(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// endpoint returns the name of the API endpoint the request is sent to,
+// e.g. "statuses/home_timeline"
+func (c *Client) endpoint(req *http.Request) string {
+	endpoint := strings.TrimPrefix(req.URL.Path, c.BaseURL.Path)
+	endpoint = strings.TrimPrefix(endpoint, "/")
+
+	if ext := path.Ext(endpoint); ext != "" {
+		endpoint = strings.TrimSuffix(endpoint, ext)
 	}
 
-	return response, err
+	return endpoint
 }
 
 // Response specifies Fanfou's response structure.
+//
+// A Response is returned by every call to Do and belongs to that call only,
+// so it can be inspected without synchronization.
 type Response struct {
 	Response   *http.Response // HTTP response
 	BodyStrPtr *string
@@ -365,6 +677,16 @@ type Response struct {
 	Meta       *ResponseMeta
 }
 
+// body returns the raw body of the response, which Do returns along with a
+// *DecodeError, or nil if there is no response
+func (r *Response) body() *string {
+	if r == nil {
+		return nil
+	}
+
+	return r.BodyStrPtr
+}
+
 // ResponseMeta represents information about the response. If all goes well,
 // only a Code key with value 200 will present. However, sometimes things
 // go wrong, and in that case ErrorType and ErrorMessage are present.
@@ -383,7 +705,19 @@ func (r *ErrorResponse) Error() string {
 		r.Response.StatusCode, r.Meta.Error)
 }
 
-// GetStatusCode gets the status code of the error response
+// Unwrap returns the sentinel error matching the API error, if any, so the
+// error can be classified with errors.Is, e.g. errors.Is(err, ErrNotFound)
+func (r *ErrorResponse) Unwrap() error {
+	return classify(r)
+}
+
+// StatusCode returns the status code of the error response
+func (r *ErrorResponse) StatusCode() int {
+	return r.Response.StatusCode
+}
+
+// GetStatusCode gets the status code of the error response as a string,
+// see StatusCode
 func (r *ErrorResponse) GetStatusCode() string {
 	return fmt.Sprintf("%d", r.Response.StatusCode)
 }
@@ -430,7 +764,16 @@ func CheckResponse(res *http.Response) error {
 		r.Meta.Error = err.Error()
 	}
 
-	if err := json.Unmarshal(data, &r.Meta); err != nil {
+	// Errors of the XML endpoints are in XML
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		decoder := xml.NewDecoder(bytes.NewReader(data))
+		decoder.Strict = false
+		err = decoder.Decode(&r.Meta)
+	} else {
+		err = json.Unmarshal(data, &r.Meta)
+	}
+
+	if err != nil {
 		r.Meta.Error = err.Error()
 	}
 
@@ -440,34 +783,32 @@ func CheckResponse(res *http.Response) error {
 // CheckAuthResponse checks the API response for error for
 // the requests during authorization, and returns it if present.
 //
-// A response is considered an error if it has non StatusOK code.
+// Errors returned by the API are returned as *ErrorResponse, other errors
+// such as network errors as *AuthError.
 func CheckAuthResponse(err error, tag string) error {
+	httpErr, ok := err.(oauth.HTTPExecuteError)
+	if !ok {
+		return &AuthError{Op: tag, Err: err}
+	}
+
 	r := new(ErrorResponse)
-	r.Response = &http.Response{StatusCode: http.StatusBadRequest}
+	r.Response = httpErr.Response
 	r.Meta = &ResponseMeta{
 		Error:   "authorization error",
 		Request: "",
 	}
 
-	if tag != "" {
-		r.Meta.Error = tag + " error: " + err.Error()
+	if httpErr.Response.StatusCode >= http.StatusInternalServerError {
+		r.Meta.Error = http.StatusText(httpErr.Response.StatusCode)
+		return r
 	}
 
-	if err, ok := err.(oauth.HTTPExecuteError); ok {
-		r.Response = err.Response
-
-		if err.Response.StatusCode >= http.StatusInternalServerError {
-			r.Meta.Error = http.StatusText(err.Response.StatusCode)
-			return r
-		}
-
-		// Fanfou auth errors with a valid body shall be in XML
-		decoder := xml.NewDecoder(strings.NewReader(string(err.ResponseBodyBytes)))
-		decoder.Strict = false
+	// Fanfou auth errors with a valid body shall be in XML
+	decoder := xml.NewDecoder(strings.NewReader(string(httpErr.ResponseBodyBytes)))
+	decoder.Strict = false
 
-		if err := decoder.Decode(&r.Meta); err != nil {
-			r.Meta.Error = err.Error()
-		}
+	if err := decoder.Decode(&r.Meta); err != nil {
+		r.Meta.Error = err.Error()
 	}
 
 	return r
@@ -2,13 +2,17 @@ package fanfou
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/mogita/oauth"
 )
@@ -22,6 +26,10 @@ var (
 
 	// server is a test HTTP server used to provide mock API responses.
 	server *httptest.Server
+
+	// serverURL is the URL of the test server, used as both the base and
+	// the auth base URL of the clients being tested.
+	serverURL *url.URL
 )
 
 func setup() {
@@ -52,18 +60,22 @@ func setup() {
 	})
 
 	// mock base url
-	mockBaseURL, _ := url.Parse(server.URL)
-	AuthBaseURL = mockBaseURL.String() + "/"
+	serverURL, _ = url.Parse(server.URL)
 
 	// Fanfou client configured to use test server
-	client = NewClient("test", "test")
-	client.BaseURL = mockBaseURL
-	err := client.AuthorizeClientWithXAuth("", "")
+	client = newTestClient("test", "test")
+	_, err := client.AuthorizeClientWithXAuth("", "")
 	if err != nil {
 		panic(err)
 	}
 }
 
+// newTestClient returns a client configured to use the test server.
+func newTestClient(consumerKey, consumerSecret string, opts ...Option) *Client {
+	opts = append([]Option{WithBaseURL(serverURL), WithAuthBaseURL(serverURL)}, opts...)
+	return NewClient(consumerKey, consumerSecret, opts...)
+}
+
 // teardown closes the test HTTP server.
 func teardown() {
 	server.Close()
@@ -102,7 +114,7 @@ func TestGetRequestTokenAndURL(t *testing.T) {
 	setup()
 	defer teardown()
 
-	c := NewClient("", "")
+	c := newTestClient("", "")
 	rToken, loginURL, err := c.GetRequestTokenAndURL("")
 	if err != nil {
 		panic(err)
@@ -125,7 +137,7 @@ func TestAuthorizeClient(t *testing.T) {
 	setup()
 	defer teardown()
 
-	c := NewClient("", "")
+	c := newTestClient("", "")
 	rToken, _, err := c.GetRequestTokenAndURL("")
 	if err != nil {
 		panic(err)
@@ -303,3 +315,194 @@ func TestCheckResponse(t *testing.T) {
 		t.Errorf("CheckResponse() while 400, fanfouErr.GetFanfouError() is %v, want %v", actual, want)
 	}
 }
+
+func TestDo_contextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	block := make(chan struct{})
+	defer close(block)
+
+	mux.HandleFunc("/foo/bar.json", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := client.NewRequestContext(ctx, http.MethodGet, "foo/bar.json", "")
+	if err != nil {
+		t.Fatalf("NewRequestContext() returned error: %v", err)
+	}
+
+	_, err = client.Do(req, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Do() with expired context returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestGetRequestTokenAndURLContext_canceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := newTestClient("", "")
+	_, _, err := c.GetRequestTokenAndURLContext(ctx, "")
+	if err != context.Canceled {
+		t.Errorf("GetRequestTokenAndURLContext() with canceled context returned %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestNewUploadRequestContext_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := NewClient("", "")
+	_, err := c.NewUploadRequestContext(ctx, "POST", "foo/bar.json", nil, "photo", "./fanfou.go")
+	if err != context.Canceled {
+		t.Errorf("NewUploadRequestContext() with canceled context returned %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestClient_concurrentUse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, err := fmt.Fprintf(w, `{"id": %q}`, r.FormValue("id"))
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// Re-authorizing while other requests are in flight shall be safe
+			if i%20 == 0 {
+				if err := client.AuthorizeClientWithAccessTokens("test", "test", nil); err != nil {
+					t.Errorf("AuthorizeClientWithAccessTokens() returned error: %v", err)
+				}
+			}
+
+			ID := fmt.Sprintf("status_%d", i)
+			status, body, err := client.Statuses.Show(ID, nil)
+			if err != nil {
+				t.Errorf("statuses.show returned error: %v", err)
+				return
+			}
+
+			if status.ID != ID {
+				t.Errorf("statuses.show returned ID %v, want %v", status.ID, ID)
+			}
+
+			want := fmt.Sprintf(`{"id": %q}`, ID)
+			if *body != want {
+				t.Errorf("statuses.show returned body %v, want %v", *body, want)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestClient_AuthorizeClientWithXAuth(t *testing.T) {
+	xauthMux := http.NewServeMux()
+	xauthServer := httptest.NewServer(xauthMux)
+	defer xauthServer.Close()
+
+	// credentialsOf returns the XAuth credentials sent with a request
+	credentialsOf := func(r *http.Request) string {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+		}
+		return r.Header.Get("Authorization") + r.Form.Encode()
+	}
+
+	xauthMux.HandleFunc("/"+accessTokenURI, func(w http.ResponseWriter, r *http.Request) {
+		if credentials := credentialsOf(r); !strings.Contains(credentials, "test_password") {
+			t.Errorf("access token request carries %q, want the XAuth credentials", credentials)
+		}
+		fmt.Fprint(w, `oauth_token=xauth_token&oauth_token_secret=xauth_secret`)
+	})
+
+	xauthMux.HandleFunc("/"+requestTokenURI, func(w http.ResponseWriter, r *http.Request) {
+		if credentials := credentialsOf(r); strings.Contains(credentials, "x_auth") {
+			t.Errorf("request token request carries %q, want no XAuth credentials", credentials)
+		}
+		fmt.Fprint(w, `oauth_token=test_token&oauth_token_secret=test_secret`)
+	})
+
+	xauthMux.HandleFunc("/account/verify_credentials.json", func(w http.ResponseWriter, r *http.Request) {
+		if credentials := credentialsOf(r); strings.Contains(credentials, "x_auth") {
+			t.Errorf("API request carries %q, want no XAuth credentials", credentials)
+		}
+		fmt.Fprint(w, `{"id": "test_user_id"}`)
+	})
+
+	xauthURL, _ := url.Parse(xauthServer.URL)
+	c := NewClient("test", "test", WithBaseURL(xauthURL), WithAuthBaseURL(xauthURL))
+
+	token, err := c.AuthorizeClientWithXAuth("test_username", "test_password")
+	if err != nil {
+		t.Fatalf("AuthorizeClientWithXAuth() returned error: %v", err)
+	}
+
+	want := &AccessToken{Token: "xauth_token", Secret: "xauth_secret", AdditionalData: map[string]string{}}
+	if !reflect.DeepEqual(token, want) {
+		t.Errorf("AuthorizeClientWithXAuth() returned %+v, want %+v", token, want)
+	}
+
+	if _, _, err := c.Account.VerifyCredentials(nil); err != nil {
+		t.Errorf("account.verify_credentials returned error: %v", err)
+	}
+
+	if _, _, err := c.GetRequestTokenAndURL("oob"); err != nil {
+		t.Errorf("GetRequestTokenAndURL() returned error: %v", err)
+	}
+}
+
+func TestClient_concurrentXAuth(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			_, err := client.AuthorizeClientWithXAuth(fmt.Sprintf("user_%d", i), "password")
+			if err != nil {
+				t.Errorf("AuthorizeClientWithXAuth() returned error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(client.oauthConsumer.AdditionalParams) != 0 {
+		t.Errorf("AuthorizeClientWithXAuth() left params %v on the consumer, want none", client.oauthConsumer.AdditionalParams)
+	}
+}
+
+func TestDo_unauthorized(t *testing.T) {
+	c := NewClient("", "")
+
+	req, err := c.NewRequest(http.MethodGet, "foo/bar.json", "")
+	if err != nil {
+		t.Fatalf("NewRequest() returned error: %v", err)
+	}
+
+	_, err = c.Do(req, nil)
+	if err == nil {
+		t.Errorf("Do() on an unauthorized client returned %v, want err", err)
+	}
+}
@@ -1,6 +1,7 @@
 package fanfou
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -31,6 +32,12 @@ type FavoritesOptParams struct {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/favorites
 func (s *FavoritesService) IDs(opt *FavoritesOptParams) ([]StatusResult, *string, error) {
+	return s.IDsContext(context.Background(), opt)
+}
+
+// IDsContext is the same as IDs, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *FavoritesService) IDsContext(ctx context.Context, opt *FavoritesOptParams) ([]StatusResult, *string, error) {
 	u := fmt.Sprintf("favorites/id.json")
 	params := url.Values{}
 
@@ -54,7 +61,7 @@ func (s *FavoritesService) IDs(opt *FavoritesOptParams) ([]StatusResult, *string
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -62,7 +69,7 @@ func (s *FavoritesService) IDs(opt *FavoritesOptParams) ([]StatusResult, *string
 	newStatuses := new([]StatusResult)
 	resp, err := s.client.Do(req, newStatuses)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newStatuses, resp.BodyStrPtr, nil
@@ -74,6 +81,12 @@ func (s *FavoritesService) IDs(opt *FavoritesOptParams) ([]StatusResult, *string
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/favorites.create
 func (s *FavoritesService) Create(ID string, opt *FavoritesOptParams) (*StatusResult, *string, error) {
+	return s.CreateContext(context.Background(), ID, opt)
+}
+
+// CreateContext is the same as Create, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *FavoritesService) CreateContext(ctx context.Context, ID string, opt *FavoritesOptParams) (*StatusResult, *string, error) {
 	u := fmt.Sprintf("favorites/create.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -88,7 +101,7 @@ func (s *FavoritesService) Create(ID string, opt *FavoritesOptParams) (*StatusRe
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -96,7 +109,7 @@ func (s *FavoritesService) Create(ID string, opt *FavoritesOptParams) (*StatusRe
 	newStatus := new(StatusResult)
 	resp, err := s.client.Do(req, newStatus)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newStatus, resp.BodyStrPtr, nil
@@ -108,6 +121,12 @@ func (s *FavoritesService) Create(ID string, opt *FavoritesOptParams) (*StatusRe
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/favorites.destroy
 func (s *FavoritesService) Destroy(ID string, opt *FavoritesOptParams) (*StatusResult, *string, error) {
+	return s.DestroyContext(context.Background(), ID, opt)
+}
+
+// DestroyContext is the same as Destroy, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *FavoritesService) DestroyContext(ctx context.Context, ID string, opt *FavoritesOptParams) (*StatusResult, *string, error) {
 	u := fmt.Sprintf("favorites/destroy.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -122,7 +141,7 @@ func (s *FavoritesService) Destroy(ID string, opt *FavoritesOptParams) (*StatusR
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -130,7 +149,7 @@ func (s *FavoritesService) Destroy(ID string, opt *FavoritesOptParams) (*StatusR
 	newStatus := new(StatusResult)
 	resp, err := s.client.Do(req, newStatus)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newStatus, resp.BodyStrPtr, nil
@@ -1,6 +1,7 @@
 package fanfou
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -29,6 +30,12 @@ type FollowersOptParams struct {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/followers.ids
 func (s *FollowersService) IDs(opt *FollowersOptParams) (*UserIDs, *string, error) {
+	return s.IDsContext(context.Background(), opt)
+}
+
+// IDsContext is the same as IDs, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *FollowersService) IDsContext(ctx context.Context, opt *FollowersOptParams) (*UserIDs, *string, error) {
 	u := fmt.Sprintf("followers/ids.json")
 	params := url.Values{}
 
@@ -46,7 +53,7 @@ func (s *FollowersService) IDs(opt *FollowersOptParams) (*UserIDs, *string, erro
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -54,7 +61,7 @@ func (s *FollowersService) IDs(opt *FollowersOptParams) (*UserIDs, *string, erro
 	newUserIDs := new(UserIDs)
 	resp, err := s.client.Do(req, newUserIDs)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUserIDs, resp.BodyStrPtr, nil
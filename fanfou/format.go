@@ -0,0 +1,183 @@
+package fanfou
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// WireFormat is the format of the API responses
+type WireFormat string
+
+const (
+	// WireFormatJSON makes the client talk to the JSON endpoints, which is
+	// the default
+	WireFormatJSON WireFormat = "json"
+
+	// WireFormatXML makes the client talk to the XML endpoints, e.g. to work
+	// around an endpoint whose JSON output is broken
+	WireFormatXML WireFormat = "xml"
+)
+
+// WithWireFormat makes the client talk to the endpoints of the given
+// format. The responses are decoded into the same result types either way,
+// only the raw bodies returned by the services differ.
+func WithWireFormat(format WireFormat) Option {
+	return func(c *Client) {
+		c.wireFormat = format
+	}
+}
+
+// formatURI returns the URI of the endpoint in the wire format of the client
+func (c *Client) formatURI(uri string) string {
+	if c.wireFormat != WireFormatXML {
+		return uri
+	}
+
+	if i := strings.Index(uri, ".json"); i >= 0 && (i+5 == len(uri) || uri[i+5] == '?') {
+		return uri[:i] + ".xml" + uri[i+5:]
+	}
+
+	return uri
+}
+
+// decode decodes the body of a response in the wire format of the client
+func (c *Client) decode(data []byte, v interface{}) error {
+	if c.wireFormat == WireFormatXML {
+		return decodeXML(data, v)
+	}
+
+	return decodeJSON(data, v, c.lenient)
+}
+
+// xmlNode is an element of an XML document
+type xmlNode struct {
+	name     string
+	text     string
+	children []*xmlNode
+}
+
+// parseXML returns the root element of the XML document
+func parseXML(data []byte) (*xmlNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+
+	var stack []*xmlNode
+	var root *xmlNode
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: token.Name.Local}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			} else if root == nil {
+				root = node
+			}
+			stack = append(stack, node)
+
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(token)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	return root, nil
+}
+
+// decodeXML decodes the XML data into v. The elements are matched with the
+// JSON names of the fields, which are the same in both formats, so the
+// result types need no XML tags.
+func decodeXML(data []byte, v interface{}) error {
+	root, err := parseXML(data)
+	if err != nil {
+		return err
+	}
+
+	t := reflect.TypeOf(v)
+
+	// Every value of an XML document is text, converted as leniently as a
+	// quoted JSON value
+	value := coerce(xmlValue(root, t), t)
+
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(jsonData, v)
+}
+
+// xmlValue converts the element to the value it would have in the JSON
+// response, so it can be decoded into a value of type t
+func xmlValue(n *xmlNode, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return strings.TrimSpace(n.text)
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		values := make([]interface{}, 0, len(n.children))
+		for _, child := range n.children {
+			values = append(values, xmlValue(child, t.Elem()))
+		}
+		return values
+
+	case reflect.Struct:
+		values := make(map[string]interface{}, len(n.children))
+		for _, child := range n.children {
+			if field, ok := jsonField(t, child.name); ok {
+				values[child.name] = xmlValue(child, field.Type)
+			}
+		}
+		return values
+
+	case reflect.Map:
+		values := make(map[string]interface{}, len(n.children))
+		for _, child := range n.children {
+			values[child.name] = xmlValue(child, t.Elem())
+		}
+		return values
+
+	case reflect.Interface:
+		if len(n.children) == 0 {
+			return n.text
+		}
+		values := make(map[string]interface{}, len(n.children))
+		for _, child := range n.children {
+			values[child.name] = xmlValue(child, t)
+		}
+		return values
+
+	case reflect.String:
+		return n.text
+	}
+
+	return strings.TrimSpace(n.text)
+}
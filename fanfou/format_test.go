@@ -0,0 +1,170 @@
+package fanfou
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// newXMLTestClient returns an authorized client talking to the XML endpoints
+func newXMLTestClient(t *testing.T) *Client {
+	c := newTestClient("test", "test", WithWireFormat(WireFormatXML))
+	if err := c.AuthorizeClientWithAccessTokens("test", "test", nil); err != nil {
+		t.Fatalf("AuthorizeClientWithAccessTokens() returned error: %v", err)
+	}
+
+	return c
+}
+
+func TestWireFormatXML_statuses(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/home_timeline.xml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"count": "2"})
+		_, err := fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<statuses type="array">
+	<status>
+		<id>test_id_1</id>
+		<rawid>1</rawid>
+		<text>test &lt;b&gt;text&lt;/b&gt;</text>
+		<favorited>true</favorited>
+		<truncated>false</truncated>
+		<user>
+			<id>test_user_id</id>
+			<protected>true</protected>
+			<followers_count>12</followers_count>
+			<utc_offset></utc_offset>
+		</user>
+	</status>
+	<status>
+		<id>test_id_2</id>
+		<rawid>2</rawid>
+		<repost_status>
+			<id>test_id_1</id>
+		</repost_status>
+	</status>
+</statuses>`)
+		if err != nil {
+			t.Errorf("statuses.home_timeline mock server error: %+v", err)
+		}
+	})
+
+	c := newXMLTestClient(t)
+
+	statuses, _, err := c.Statuses.HomeTimeline(&StatusesOptParams{Count: 2})
+	if err != nil {
+		t.Fatalf("statuses.home_timeline returned error: %v", err)
+	}
+
+	want := []StatusResult{
+		{
+			ID:        "test_id_1",
+			Rawid:     1,
+			Text:      "test <b>text</b>",
+			Favorited: true,
+		},
+		{
+			ID:           "test_id_2",
+			Rawid:        2,
+			RepostStatus: &StatusResult{ID: "test_id_1"},
+		},
+	}
+	want[0].User = &UserResult{
+		ID:             "test_user_id",
+		Protected:      true,
+		FollowersCount: 12,
+	}
+
+	if !reflect.DeepEqual(statuses, want) {
+		t.Errorf("statuses.home_timeline returned %+v, want %+v", statuses, want)
+	}
+}
+
+func TestWireFormatXML_scalars(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/followers/ids.xml", func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprint(w, `<ids><id>test_id_1</id><id>test_id_2</id></ids>`)
+		if err != nil {
+			t.Errorf("followers.ids mock server error: %+v", err)
+		}
+	})
+
+	mux.HandleFunc("/friendships/exists.xml", func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprint(w, `<friends>true</friends>`)
+		if err != nil {
+			t.Errorf("friendships.exists mock server error: %+v", err)
+		}
+	})
+
+	c := newXMLTestClient(t)
+
+	ids, _, err := c.Followers.IDs(nil)
+	if err != nil {
+		t.Fatalf("followers.ids returned error: %v", err)
+	}
+
+	if want := (UserIDs{"test_id_1", "test_id_2"}); !reflect.DeepEqual(*ids, want) {
+		t.Errorf("followers.ids returned %+v, want %+v", *ids, want)
+	}
+
+	exists, _, err := c.Friendships.Exists("test_user_a", "test_user_b")
+	if err != nil {
+		t.Fatalf("friendships.exists returned error: %v", err)
+	}
+
+	if !exists {
+		t.Errorf("friendships.exists returned %v, want %v", exists, true)
+	}
+}
+
+func TestWireFormatXML_error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/show.xml", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><hash><request>/statuses/show.xml</request><error>test_error</error></hash>`)
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	c := newXMLTestClient(t)
+
+	_, _, err := c.Statuses.Show("test_id", nil)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("statuses.show returned %v, want ErrNotFound", err)
+	}
+
+	var fanfouErr *ErrorResponse
+	if errors.As(err, &fanfouErr) && fanfouErr.GetFanfouError() != "test_error" {
+		t.Errorf("ErrorResponse.GetFanfouError() = %v, want %v", fanfouErr.GetFanfouError(), "test_error")
+	}
+}
+
+func TestClient_formatURI(t *testing.T) {
+	c := NewClient("test", "test", WithWireFormat(WireFormatXML))
+
+	tests := map[string]string{
+		"statuses/show.json?id=test_id": "statuses/show.xml?id=test_id",
+		"statuses/update.json":          "statuses/update.xml",
+		"statuses/show.xml":             "statuses/show.xml",
+		"photos/upload":                 "photos/upload",
+	}
+
+	for uri, want := range tests {
+		if got := c.formatURI(uri); got != want {
+			t.Errorf("formatURI(%q) = %v, want %v", uri, got, want)
+		}
+	}
+
+	if got := NewClient("test", "test").formatURI("statuses/show.json"); got != "statuses/show.json" {
+		t.Errorf("formatURI() of a JSON client = %v, want %v", got, "statuses/show.json")
+	}
+}
@@ -1,6 +1,7 @@
 package fanfou
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -29,6 +30,12 @@ type FriendsOptParams struct {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/friends.ids
 func (s *FriendsService) IDs(opt *FriendsOptParams) (*UserIDs, *string, error) {
+	return s.IDsContext(context.Background(), opt)
+}
+
+// IDsContext is the same as IDs, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *FriendsService) IDsContext(ctx context.Context, opt *FriendsOptParams) (*UserIDs, *string, error) {
 	u := fmt.Sprintf("friends/ids.json")
 	params := url.Values{}
 
@@ -46,7 +53,7 @@ func (s *FriendsService) IDs(opt *FriendsOptParams) (*UserIDs, *string, error) {
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -54,7 +61,7 @@ func (s *FriendsService) IDs(opt *FriendsOptParams) (*UserIDs, *string, error) {
 	newUserIDs := new(UserIDs)
 	resp, err := s.client.Do(req, newUserIDs)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUserIDs, resp.BodyStrPtr, nil
@@ -1,6 +1,7 @@
 package fanfou
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -60,6 +61,12 @@ type FriendshipsShowOptParams struct {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/friendships.create
 func (s *FriendshipsService) Create(ID string, opt *FriendshipsOptParams) (*UserResult, *string, error) {
+	return s.CreateContext(context.Background(), ID, opt)
+}
+
+// CreateContext is the same as Create, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *FriendshipsService) CreateContext(ctx context.Context, ID string, opt *FriendshipsOptParams) (*UserResult, *string, error) {
 	u := fmt.Sprintf("friendships/create.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -71,7 +78,7 @@ func (s *FriendshipsService) Create(ID string, opt *FriendshipsOptParams) (*User
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -79,7 +86,7 @@ func (s *FriendshipsService) Create(ID string, opt *FriendshipsOptParams) (*User
 	newUser := new(UserResult)
 	resp, err := s.client.Do(req, newUser)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUser, resp.BodyStrPtr, nil
@@ -91,6 +98,12 @@ func (s *FriendshipsService) Create(ID string, opt *FriendshipsOptParams) (*User
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/friendships.destroy
 func (s *FriendshipsService) Destroy(ID string, opt *FriendshipsOptParams) (*UserResult, *string, error) {
+	return s.DestroyContext(context.Background(), ID, opt)
+}
+
+// DestroyContext is the same as Destroy, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *FriendshipsService) DestroyContext(ctx context.Context, ID string, opt *FriendshipsOptParams) (*UserResult, *string, error) {
 	u := fmt.Sprintf("friendships/destroy.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -105,7 +118,7 @@ func (s *FriendshipsService) Destroy(ID string, opt *FriendshipsOptParams) (*Use
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -113,7 +126,7 @@ func (s *FriendshipsService) Destroy(ID string, opt *FriendshipsOptParams) (*Use
 	newUser := new(UserResult)
 	resp, err := s.client.Do(req, newUser)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUser, resp.BodyStrPtr, nil
@@ -124,6 +137,12 @@ func (s *FriendshipsService) Destroy(ID string, opt *FriendshipsOptParams) (*Use
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/friendships.requests
 func (s *FriendshipsService) Requests(opt *FriendshipsOptParams) ([]UserResult, *string, error) {
+	return s.RequestsContext(context.Background(), opt)
+}
+
+// RequestsContext is the same as Requests, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *FriendshipsService) RequestsContext(ctx context.Context, opt *FriendshipsOptParams) ([]UserResult, *string, error) {
 	u := fmt.Sprintf("friendships/requests.json")
 	params := url.Values{}
 
@@ -144,7 +163,7 @@ func (s *FriendshipsService) Requests(opt *FriendshipsOptParams) ([]UserResult,
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -152,7 +171,7 @@ func (s *FriendshipsService) Requests(opt *FriendshipsOptParams) ([]UserResult,
 	newUsers := new([]UserResult)
 	resp, err := s.client.Do(req, newUsers)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newUsers, resp.BodyStrPtr, nil
@@ -164,6 +183,12 @@ func (s *FriendshipsService) Requests(opt *FriendshipsOptParams) ([]UserResult,
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/friendships.deny
 func (s *FriendshipsService) Deny(ID string, opt *FriendshipsOptParams) (*UserResult, *string, error) {
+	return s.DenyContext(context.Background(), ID, opt)
+}
+
+// DenyContext is the same as Deny, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *FriendshipsService) DenyContext(ctx context.Context, ID string, opt *FriendshipsOptParams) (*UserResult, *string, error) {
 	u := fmt.Sprintf("friendships/deny.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -178,7 +203,7 @@ func (s *FriendshipsService) Deny(ID string, opt *FriendshipsOptParams) (*UserRe
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -186,7 +211,7 @@ func (s *FriendshipsService) Deny(ID string, opt *FriendshipsOptParams) (*UserRe
 	newUser := new(UserResult)
 	resp, err := s.client.Do(req, newUser)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUser, resp.BodyStrPtr, nil
@@ -198,6 +223,12 @@ func (s *FriendshipsService) Deny(ID string, opt *FriendshipsOptParams) (*UserRe
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/friendships.accept
 func (s *FriendshipsService) Accept(ID string, opt *FriendshipsOptParams) (*UserResult, *string, error) {
+	return s.AcceptContext(context.Background(), ID, opt)
+}
+
+// AcceptContext is the same as Accept, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *FriendshipsService) AcceptContext(ctx context.Context, ID string, opt *FriendshipsOptParams) (*UserResult, *string, error) {
 	u := fmt.Sprintf("friendships/accept.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -212,7 +243,7 @@ func (s *FriendshipsService) Accept(ID string, opt *FriendshipsOptParams) (*User
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -220,7 +251,7 @@ func (s *FriendshipsService) Accept(ID string, opt *FriendshipsOptParams) (*User
 	newUser := new(UserResult)
 	resp, err := s.client.Do(req, newUser)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUser, resp.BodyStrPtr, nil
@@ -232,6 +263,12 @@ func (s *FriendshipsService) Accept(ID string, opt *FriendshipsOptParams) (*User
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/friendships.exists
 func (s *FriendshipsService) Exists(userA, userB string) (bool, *string, error) {
+	return s.ExistsContext(context.Background(), userA, userB)
+}
+
+// ExistsContext is the same as Exists, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *FriendshipsService) ExistsContext(ctx context.Context, userA, userB string) (bool, *string, error) {
 	u := fmt.Sprintf("friendships/exists.json")
 	params := url.Values{
 		"user_a": []string{userA},
@@ -240,7 +277,7 @@ func (s *FriendshipsService) Exists(userA, userB string) (bool, *string, error)
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return false, nil, err
 	}
@@ -248,7 +285,7 @@ func (s *FriendshipsService) Exists(userA, userB string) (bool, *string, error)
 	result := new(bool)
 	resp, err := s.client.Do(req, result)
 	if err != nil {
-		return false, nil, err
+		return false, resp.body(), err
 	}
 
 	return *result, resp.BodyStrPtr, nil
@@ -260,6 +297,12 @@ func (s *FriendshipsService) Exists(userA, userB string) (bool, *string, error)
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/friendships.show
 func (s *FriendshipsService) Show(opt *FriendshipsShowOptParams) (*FriendshipsShowResult, *string, error) {
+	return s.ShowContext(context.Background(), opt)
+}
+
+// ShowContext is the same as Show, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *FriendshipsService) ShowContext(ctx context.Context, opt *FriendshipsShowOptParams) (*FriendshipsShowResult, *string, error) {
 	u := fmt.Sprintf("friendships/show.json")
 	params := url.Values{}
 
@@ -280,7 +323,7 @@ func (s *FriendshipsService) Show(opt *FriendshipsShowOptParams) (*FriendshipsSh
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -288,7 +331,7 @@ func (s *FriendshipsService) Show(opt *FriendshipsShowOptParams) (*FriendshipsSh
 	result := new(FriendshipsShowResult)
 	resp, err := s.client.Do(req, result)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return result, resp.BodyStrPtr, nil
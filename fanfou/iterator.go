@@ -0,0 +1,302 @@
+package fanfou
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// IterOptParams specifies when the iteration of a timeline stops, besides
+// when the timeline is exhausted or an error occurs
+type IterOptParams struct {
+	// Limit is the maximum number of items yielded in total, 0 for no limit
+	Limit int
+
+	// StopID is the ID of an item the iteration stops at, e.g. the newest
+	// item seen last time. The item itself is not yielded.
+	StopID string
+
+	// StopTime is the time the iteration stops at: items created before it
+	// are not yielded. Items without a creation time never stop it.
+	StopTime time.Time
+}
+
+// timelineItem is an item of a timeline, which can be walked backwards by
+// ID and creation time
+type timelineItem interface {
+	StatusResult | DirectMessageResult
+}
+
+// itemKey returns the ID and creation time of a timeline item
+func itemKey[T timelineItem](item *T) (string, time.Time) {
+	switch v := any(item).(type) {
+	case *StatusResult:
+		return v.ID, v.CreatedAt.GetTime()
+	case *DirectMessageResult:
+		return v.ID, v.CreatedAt.GetTime()
+	}
+
+	return "", time.Time{}
+}
+
+// paginate walks a timeline backwards, fetching every page with the ID of
+// the oldest item of the previous page as max_id, or an empty max_id for the
+// first page. As max_id is inclusive, the items of the previous page are
+// skipped.
+func paginate[T timelineItem](ctx context.Context, opt *IterOptParams, fetch func(ctx context.Context, maxID string) ([]T, error)) iter.Seq2[T, error] {
+	if opt == nil {
+		opt = &IterOptParams{}
+	}
+
+	return func(yield func(T, error) bool) {
+		var seen map[string]bool
+		maxID := ""
+		yielded := 0
+
+		for {
+			items, err := fetch(ctx, maxID)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			page := make(map[string]bool, len(items))
+			for i := range items {
+				id, createdAt := itemKey(&items[i])
+				if seen[id] || page[id] {
+					continue
+				}
+				page[id] = true
+
+				if opt.StopID != "" && id == opt.StopID {
+					return
+				}
+				if !opt.StopTime.IsZero() && !createdAt.IsZero() && createdAt.Before(opt.StopTime) {
+					return
+				}
+
+				if !yield(items[i], nil) {
+					return
+				}
+
+				yielded++
+				if opt.Limit > 0 && yielded >= opt.Limit {
+					return
+				}
+			}
+
+			// A page without any new item is the end of the timeline
+			if len(page) == 0 {
+				return
+			}
+
+			maxID, _ = itemKey(&items[len(items)-1])
+			seen = page
+		}
+	}
+}
+
+// page returns a copy of opt fetching the page up to maxID, or up to
+// opt.MaxID if maxID is empty
+func (opt *StatusesOptParams) page(maxID string) *StatusesOptParams {
+	p := StatusesOptParams{}
+	if opt != nil {
+		p = *opt
+	}
+	if maxID != "" {
+		p.MaxID = maxID
+	}
+	p.Page = 0
+
+	return &p
+}
+
+// page returns a copy of opt fetching the page up to maxID, or up to
+// opt.MaxID if maxID is empty
+func (opt *PhotosOptParams) page(maxID string) *PhotosOptParams {
+	p := PhotosOptParams{}
+	if opt != nil {
+		p = *opt
+	}
+	if maxID != "" {
+		p.MaxID = maxID
+	}
+	p.Page = 0
+
+	return &p
+}
+
+// page returns a copy of opt fetching the page up to maxID, or up to
+// opt.MaxID if maxID is empty
+func (opt *SearchOptParams) page(maxID string) *SearchOptParams {
+	p := SearchOptParams{}
+	if opt != nil {
+		p = *opt
+	}
+	if maxID != "" {
+		p.MaxID = maxID
+	}
+	p.Page = 0
+
+	return &p
+}
+
+// page returns a copy of opt fetching the page up to maxID, or up to
+// opt.MaxID if maxID is empty
+func (opt *DirectMessagesOptParams) page(maxID string) *DirectMessagesOptParams {
+	p := DirectMessagesOptParams{}
+	if opt != nil {
+		p = *opt
+	}
+	if maxID != "" {
+		p.MaxID = maxID
+	}
+	p.Page = 0
+
+	return &p
+}
+
+// HomeTimelineIter shall iterate over the home timeline, from the newest
+// status to the oldest, fetching the pages as needed. The iteration starts
+// at opt.MaxID if set, and stops as specified by iopt. An error ends the
+// iteration, after being yielded.
+//
+//	for status, err := range c.Statuses.HomeTimelineIter(nil, &fanfou.IterOptParams{Limit: 200}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(status.Text)
+//	}
+func (s *StatusesService) HomeTimelineIter(opt *StatusesOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return s.HomeTimelineIterContext(context.Background(), opt, iopt)
+}
+
+// HomeTimelineIterContext is the same as HomeTimelineIter, except that the
+// requests are bound to ctx and aborted once ctx is done
+func (s *StatusesService) HomeTimelineIterContext(ctx context.Context, opt *StatusesOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return paginate(ctx, iopt, func(ctx context.Context, maxID string) ([]StatusResult, error) {
+		statuses, _, err := s.HomeTimelineContext(ctx, opt.page(maxID))
+		return statuses, err
+	})
+}
+
+// PublicTimelineIter shall iterate over the public timeline, see
+// HomeTimelineIter
+func (s *StatusesService) PublicTimelineIter(opt *StatusesOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return s.PublicTimelineIterContext(context.Background(), opt, iopt)
+}
+
+// PublicTimelineIterContext is the same as PublicTimelineIter, except that
+// the requests are bound to ctx and aborted once ctx is done
+func (s *StatusesService) PublicTimelineIterContext(ctx context.Context, opt *StatusesOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return paginate(ctx, iopt, func(ctx context.Context, maxID string) ([]StatusResult, error) {
+		statuses, _, err := s.PublicTimelineContext(ctx, opt.page(maxID))
+		return statuses, err
+	})
+}
+
+// UserTimelineIter shall iterate over the timeline of the specified user,
+// or of the current user if no ID specified, see HomeTimelineIter
+func (s *StatusesService) UserTimelineIter(opt *StatusesOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return s.UserTimelineIterContext(context.Background(), opt, iopt)
+}
+
+// UserTimelineIterContext is the same as UserTimelineIter, except that the
+// requests are bound to ctx and aborted once ctx is done
+func (s *StatusesService) UserTimelineIterContext(ctx context.Context, opt *StatusesOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return paginate(ctx, iopt, func(ctx context.Context, maxID string) ([]StatusResult, error) {
+		statuses, _, err := s.UserTimelineContext(ctx, opt.page(maxID))
+		return statuses, err
+	})
+}
+
+// RepliesIter shall iterate over the replies to the current user, see
+// HomeTimelineIter
+func (s *StatusesService) RepliesIter(opt *StatusesOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return s.RepliesIterContext(context.Background(), opt, iopt)
+}
+
+// RepliesIterContext is the same as RepliesIter, except that the requests
+// are bound to ctx and aborted once ctx is done
+func (s *StatusesService) RepliesIterContext(ctx context.Context, opt *StatusesOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return paginate(ctx, iopt, func(ctx context.Context, maxID string) ([]StatusResult, error) {
+		statuses, _, err := s.RepliesContext(ctx, opt.page(maxID))
+		return statuses, err
+	})
+}
+
+// MentionsIter shall iterate over the mentions of the current user, see
+// HomeTimelineIter
+func (s *StatusesService) MentionsIter(opt *StatusesOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return s.MentionsIterContext(context.Background(), opt, iopt)
+}
+
+// MentionsIterContext is the same as MentionsIter, except that the requests
+// are bound to ctx and aborted once ctx is done
+func (s *StatusesService) MentionsIterContext(ctx context.Context, opt *StatusesOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return paginate(ctx, iopt, func(ctx context.Context, maxID string) ([]StatusResult, error) {
+		statuses, _, err := s.MentionsContext(ctx, opt.page(maxID))
+		return statuses, err
+	})
+}
+
+// UserTimelineIter shall iterate over the photos of the specified user, or
+// of the current user if no ID specified, see StatusesService.HomeTimelineIter
+func (s *PhotosService) UserTimelineIter(opt *PhotosOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return s.UserTimelineIterContext(context.Background(), opt, iopt)
+}
+
+// UserTimelineIterContext is the same as UserTimelineIter, except that the
+// requests are bound to ctx and aborted once ctx is done
+func (s *PhotosService) UserTimelineIterContext(ctx context.Context, opt *PhotosOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return paginate(ctx, iopt, func(ctx context.Context, maxID string) ([]StatusResult, error) {
+		statuses, _, err := s.UserTimelineContext(ctx, opt.page(maxID))
+		return statuses, err
+	})
+}
+
+// PublicTimelineIter shall iterate over the statuses of the whole platform
+// matching q, see StatusesService.HomeTimelineIter
+func (s *SearchService) PublicTimelineIter(q string, opt *SearchOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return s.PublicTimelineIterContext(context.Background(), q, opt, iopt)
+}
+
+// PublicTimelineIterContext is the same as PublicTimelineIter, except that
+// the requests are bound to ctx and aborted once ctx is done
+func (s *SearchService) PublicTimelineIterContext(ctx context.Context, q string, opt *SearchOptParams, iopt *IterOptParams) iter.Seq2[StatusResult, error] {
+	return paginate(ctx, iopt, func(ctx context.Context, maxID string) ([]StatusResult, error) {
+		statuses, _, err := s.PublicTimelineContext(ctx, q, opt.page(maxID))
+		return statuses, err
+	})
+}
+
+// InboxIter shall iterate over the received direct messages, see
+// StatusesService.HomeTimelineIter
+func (s *DirectMessagesService) InboxIter(opt *DirectMessagesOptParams, iopt *IterOptParams) iter.Seq2[DirectMessageResult, error] {
+	return s.InboxIterContext(context.Background(), opt, iopt)
+}
+
+// InboxIterContext is the same as InboxIter, except that the requests are
+// bound to ctx and aborted once ctx is done
+func (s *DirectMessagesService) InboxIterContext(ctx context.Context, opt *DirectMessagesOptParams, iopt *IterOptParams) iter.Seq2[DirectMessageResult, error] {
+	return paginate(ctx, iopt, func(ctx context.Context, maxID string) ([]DirectMessageResult, error) {
+		messages, _, err := s.InboxContext(ctx, opt.page(maxID))
+		return messages, err
+	})
+}
+
+// SentIter shall iterate over the sent direct messages, see
+// StatusesService.HomeTimelineIter
+func (s *DirectMessagesService) SentIter(opt *DirectMessagesOptParams, iopt *IterOptParams) iter.Seq2[DirectMessageResult, error] {
+	return s.SentIterContext(context.Background(), opt, iopt)
+}
+
+// SentIterContext is the same as SentIter, except that the requests are
+// bound to ctx and aborted once ctx is done
+func (s *DirectMessagesService) SentIterContext(ctx context.Context, opt *DirectMessagesOptParams, iopt *IterOptParams) iter.Seq2[DirectMessageResult, error] {
+	return paginate(ctx, iopt, func(ctx context.Context, maxID string) ([]DirectMessageResult, error) {
+		messages, _, err := s.SentContext(ctx, opt.page(maxID))
+		return messages, err
+	})
+}
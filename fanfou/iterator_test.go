@@ -0,0 +1,197 @@
+package fanfou
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// testTimelineEpoch is the creation time of the oldest item of the test
+// timelines, every newer item being created a minute later
+var testTimelineEpoch = time.Date(2019, 8, 1, 0, 0, 0, 0, time.UTC)
+
+// serveTestTimeline serves a timeline of n items, with IDs from "1" to n
+// and newest first, in pages of size items. max_id is inclusive, as it is
+// on Fanfou.
+func serveTestTimeline(t *testing.T, pattern string, n, size int) *[]string {
+	var maxIDs []string
+
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		maxID := r.FormValue("max_id")
+		maxIDs = append(maxIDs, maxID)
+
+		newest := n
+		if maxID != "" {
+			newest, _ = strconv.Atoi(maxID)
+		}
+
+		items := []map[string]string{}
+		for id := newest; id > 0 && len(items) < size; id-- {
+			items = append(items, map[string]string{
+				"id":         strconv.Itoa(id),
+				"created_at": testTimelineEpoch.Add(time.Duration(id) * time.Minute).Format(TimeLayout),
+			})
+		}
+
+		if err := json.NewEncoder(w).Encode(items); err != nil {
+			t.Errorf("%s mock server error: %+v", pattern, err)
+		}
+	})
+
+	return &maxIDs
+}
+
+func TestStatusesService_HomeTimelineIter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	maxIDs := serveTestTimeline(t, "/statuses/home_timeline.json", 7, 3)
+
+	var ids []string
+	for status, err := range client.Statuses.HomeTimelineIter(&StatusesOptParams{Count: 3, Page: 2}, nil) {
+		if err != nil {
+			t.Fatalf("statuses.home_timeline_iter returned error: %v", err)
+		}
+		ids = append(ids, status.ID)
+	}
+
+	want := []string{"7", "6", "5", "4", "3", "2", "1"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("statuses.home_timeline_iter returned %v, want %v", ids, want)
+	}
+
+	wantMaxIDs := []string{"", "5", "3", "1"}
+	if !reflect.DeepEqual(*maxIDs, wantMaxIDs) {
+		t.Errorf("statuses.home_timeline_iter requested max_id %q, want %q", *maxIDs, wantMaxIDs)
+	}
+}
+
+func TestStatusesService_HomeTimelineIterStopTimeUnknown(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// The newest status has no creation time
+	mux.HandleFunc("/statuses/home_timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("max_id") != "" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprintf(w, `[{"id": "3"}, {"id": "2", "created_at": %q}, {"id": "1", "created_at": %q}]`,
+			testTimelineEpoch.Add(2*time.Minute).Format(TimeLayout), testTimelineEpoch.Format(TimeLayout))
+	})
+
+	statuses, err := Collect(client.Statuses.HomeTimelineIter(nil, &IterOptParams{StopTime: testTimelineEpoch.Add(time.Minute)}))
+	if err != nil {
+		t.Fatalf("statuses.home_timeline_iter returned error: %v", err)
+	}
+
+	var got []string
+	for _, status := range statuses {
+		got = append(got, status.ID)
+	}
+
+	if want := []string{"3", "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("statuses.home_timeline_iter returned %v, want %v", got, want)
+	}
+}
+
+func TestStatusesService_HomeTimelineIterStop(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveTestTimeline(t, "/statuses/home_timeline.json", 10, 4)
+
+	tests := []struct {
+		opt  *StatusesOptParams
+		iopt *IterOptParams
+		want []string
+	}{
+		{nil, &IterOptParams{Limit: 5}, []string{"10", "9", "8", "7", "6"}},
+		{nil, &IterOptParams{StopID: "7"}, []string{"10", "9", "8"}},
+		{nil, &IterOptParams{StopTime: testTimelineEpoch.Add(8 * time.Minute)}, []string{"10", "9", "8"}},
+		{&StatusesOptParams{MaxID: "3"}, nil, []string{"3", "2", "1"}},
+	}
+
+	for _, tt := range tests {
+		var ids []string
+		for status, err := range client.Statuses.HomeTimelineIter(tt.opt, tt.iopt) {
+			if err != nil {
+				t.Fatalf("statuses.home_timeline_iter returned error: %v", err)
+			}
+			ids = append(ids, status.ID)
+		}
+
+		if !reflect.DeepEqual(ids, tt.want) {
+			t.Errorf("statuses.home_timeline_iter with %+v returned %v, want %v", tt.iopt, ids, tt.want)
+		}
+	}
+
+	var ids []string
+	for status := range client.Statuses.HomeTimelineIter(nil, nil) {
+		if ids = append(ids, status.ID); len(ids) == 2 {
+			break
+		}
+	}
+
+	if want := []string{"10", "9"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("statuses.home_timeline_iter broken early returned %v, want %v", ids, want)
+	}
+}
+
+func TestStatusesService_MentionsIterError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/mentions.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("max_id") != "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": "test error"}`)
+			return
+		}
+
+		fmt.Fprint(w, `[{"id": "2"}, {"id": "1"}]`)
+	})
+
+	var ids []string
+	var errs []error
+	for status, err := range client.Statuses.MentionsIter(nil, nil) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, status.ID)
+	}
+
+	if want := []string{"2", "1"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("statuses.mentions_iter returned %v, want %v", ids, want)
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[0], ErrUnauthorized) {
+		t.Errorf("statuses.mentions_iter returned errors %v, want a single ErrUnauthorized", errs)
+	}
+}
+
+func TestDirectMessagesService_InboxIter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveTestTimeline(t, "/direct_messages/inbox.json", 5, 2)
+
+	var ids []string
+	for message, err := range client.DirectMessages.InboxIter(nil, &IterOptParams{Limit: 4}) {
+		if err != nil {
+			t.Fatalf("direct_messages.inbox_iter returned error: %v", err)
+		}
+		ids = append(ids, message.ID)
+	}
+
+	if want := []string{"5", "4", "3", "2"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("direct_messages.inbox_iter returned %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,175 @@
+package fanfou
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces the secrets in the traces of a Client
+const redacted = "[REDACTED]"
+
+// secretParams lists the OAuth and XAuth params never written to the logs
+var secretParams = map[string]bool{
+	"oauth_signature":    true,
+	"oauth_token":        true,
+	"oauth_token_secret": true,
+	"oauth_verifier":     true,
+	"x_auth_password":    true,
+}
+
+// privateParams lists the params of an endpoint holding private content,
+// which is never written to the logs either
+var privateParams = map[string]map[string]bool{
+	"direct_messages/new": {"text": true},
+}
+
+// authHeaderParam matches a key="value" pair of an OAuth Authorization header
+var authHeaderParam = regexp.MustCompile(`([a-z_]+)="([^"]*)"`)
+
+// textField matches the text of the direct messages in a JSON or an XML body
+var textField = regexp.MustCompile(`"text"\s*:\s*"(?:[^"\\]|\\.)*"|<text>[^<]*</text>`)
+
+// A Logger receives the traces of the requests sent by a Client, and the
+// errors which cannot be returned to the caller. A *slog.Logger satisfies
+// it, and so do most structured loggers with a thin adapter.
+//
+// Requests are traced at the debug level, with args as alternating keys and
+// values. OAuth signatures, tokens, XAuth passwords and the text of direct
+// messages are redacted before reaching the logger.
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...interface{})
+	WarnContext(ctx context.Context, msg string, args ...interface{})
+}
+
+// WithLogger makes the client report to logger, see Logger
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// debugLogger returns the logger used when debugging is enabled without a
+// logger of its own, which writes to stderr
+func debugLogger() Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// logDebug writes a trace to the logger of the client, if any
+func (c *Client) logDebug(ctx context.Context, msg string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.DebugContext(ctx, msg, args...)
+	}
+}
+
+// logWarn writes a warning to the logger of the client, if any
+func (c *Client) logWarn(ctx context.Context, msg string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.WarnContext(ctx, msg, args...)
+	}
+}
+
+// traceRequest writes the request about to be sent to the logger
+func (c *Client) traceRequest(msg string, req *http.Request) {
+	if c.logger == nil {
+		return
+	}
+
+	endpoint := c.endpoint(req)
+	args := []interface{}{
+		"method", req.Method,
+		"url", redactURL(endpoint, req.URL),
+	}
+
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		args = append(args, "authorization", redactAuthHeader(auth))
+	}
+
+	if body := formBody(req); body != "" {
+		args = append(args, "body", redactForm(endpoint, body))
+	}
+
+	c.logger.DebugContext(req.Context(), msg, args...)
+}
+
+// redactURL returns the URL with the secrets of its query redacted
+func redactURL(endpoint string, u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+
+	newURL := *u
+	newURL.RawQuery = redactForm(endpoint, u.RawQuery)
+
+	return newURL.String()
+}
+
+// redactForm returns the URL-encoded form with its secrets redacted
+func redactForm(endpoint, form string) string {
+	values, err := url.ParseQuery(form)
+	if err != nil {
+		return redacted
+	}
+
+	for key := range values {
+		if secretParams[key] || privateParams[endpoint][key] {
+			values[key] = []string{redacted}
+		}
+	}
+
+	return values.Encode()
+}
+
+// redactAuthHeader returns the OAuth Authorization header with its secrets
+// redacted
+func redactAuthHeader(header string) string {
+	return authHeaderParam.ReplaceAllStringFunc(header, func(pair string) string {
+		key := authHeaderParam.FindStringSubmatch(pair)[1]
+		if secretParams[key] {
+			return key + `="` + redacted + `"`
+		}
+		return pair
+	})
+}
+
+// redactBody returns the body of a response with its private content
+// redacted
+func redactBody(endpoint string, body []byte) string {
+	if !strings.HasPrefix(endpoint, "direct_messages/") {
+		return string(body)
+	}
+
+	return textField.ReplaceAllStringFunc(string(body), func(field string) string {
+		if strings.HasPrefix(field, "<") {
+			return "<text>" + redacted + "</text>"
+		}
+		return `"text":"` + redacted + `"`
+	})
+}
+
+// formBody returns the URL-encoded body of the request, without consuming
+// it, or an empty string if there is none
+func formBody(req *http.Request) string {
+	if req.GetBody == nil || req.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+
+	return string(bytes.TrimSpace(data))
+}
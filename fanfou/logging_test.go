@@ -0,0 +1,99 @@
+package fanfou
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var _ Logger = (*slog.Logger)(nil)
+
+func TestLogger_redactsSecrets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/direct_messages/new.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		_, err := fmt.Fprint(w, `{"id": "test_id", "text": "test_secret_text"}`)
+		if err != nil {
+			t.Errorf("direct_messages.new mock server error: %+v", err)
+		}
+	})
+
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c := newTestClient("test", "test", WithLogger(logger))
+	if _, err := c.AuthorizeClientWithXAuth("test_username", "test_password"); err != nil {
+		t.Fatalf("AuthorizeClientWithXAuth returned error: %v", err)
+	}
+
+	if _, _, err := c.DirectMessages.New("test_id", "test_secret_text", nil); err != nil {
+		t.Fatalf("direct_messages.new returned error: %v", err)
+	}
+
+	logs := buf.String()
+	for _, want := range []string{"fanfou auth request", "fanfou request", "fanfou response", "direct_messages/new", redacted} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("Logs do not contain %q:\n%s", want, logs)
+		}
+	}
+
+	for _, secret := range []string{"test_password", "test_secret_text"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("Logs contain %q:\n%s", secret, logs)
+		}
+	}
+}
+
+func TestNewClient_debug(t *testing.T) {
+	if c := NewClient("test", "test"); c.logger != nil {
+		t.Errorf("NewClient() logger = %v, want nil", c.logger)
+	}
+
+	if c := NewClient("test", "test", WithDebug(true)); c.logger == nil {
+		t.Errorf("NewClient(WithDebug(true)) logger = nil, want the stderr logger")
+	}
+}
+
+func TestRedactAuthHeader(t *testing.T) {
+	header := `OAuth oauth_consumer_key="test_key", oauth_signature="test_signature", oauth_token="test_token", x_auth_password="test_password", x_auth_username="test_username"`
+
+	got := redactAuthHeader(header)
+	want := `OAuth oauth_consumer_key="test_key", oauth_signature="[REDACTED]", oauth_token="[REDACTED]", x_auth_password="[REDACTED]", x_auth_username="test_username"`
+	if got != want {
+		t.Errorf("redactAuthHeader returned %v, want %v", got, want)
+	}
+}
+
+func TestRedactForm(t *testing.T) {
+	form := "oauth_token=test_token&text=test_text&user=test_user"
+
+	got := redactForm("direct_messages/new", form)
+	want := "oauth_token=%5BREDACTED%5D&text=%5BREDACTED%5D&user=test_user"
+	if got != want {
+		t.Errorf("redactForm returned %v, want %v", got, want)
+	}
+
+	got = redactForm("statuses/update", "status=test_text")
+	if want := "status=test_text"; got != want {
+		t.Errorf("redactForm returned %v, want %v", got, want)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := []byte(`[{"id": "test_id", "text": "test \"quoted\" text"}]`)
+
+	got := redactBody("direct_messages/inbox", body)
+	want := `[{"id": "test_id", "text":"[REDACTED]"}]`
+	if got != want {
+		t.Errorf("redactBody returned %v, want %v", got, want)
+	}
+
+	if got := redactBody("statuses/show", body); got != string(body) {
+		t.Errorf("redactBody returned %v, want %v", got, string(body))
+	}
+}
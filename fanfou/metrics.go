@@ -0,0 +1,293 @@
+package fanfou
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds of the latency histograms of
+// a Metrics which does not specify its own
+var DefaultLatencyBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// A MetricsCollector receives the measurements of the requests sent by a
+// Client, e.g. to export them to Prometheus. Metrics is an in-memory
+// implementation.
+//
+// Its methods may be called concurrently by multiple goroutines.
+type MetricsCollector interface {
+	// ObserveRequest is called once every call to Do is done
+	ObserveRequest(m RequestMetrics)
+
+	// ObserveRateLimit is called after every call to Do with the remaining
+	// budget of API calls, when it is known
+	ObserveRateLimit(endpoint string, remaining int64)
+}
+
+// RequestMetrics describes a call to Do
+type RequestMetrics struct {
+	// Endpoint is the API endpoint, e.g. "statuses/home_timeline"
+	Endpoint string
+
+	// Method is the HTTP method of the request
+	Method string
+
+	// StatusCode is the status code of the response, or 0 if none was
+	// received
+	StatusCode int
+
+	// Duration is the time spent in Do, retries included
+	Duration time.Duration
+
+	// Err is the error returned by Do, if any
+	Err error
+}
+
+// A Tracer starts a span around every call to Do, in the fashion of
+// OpenTelemetry, whose tracers can be adapted in a few lines.
+type Tracer interface {
+	// Start starts a span and returns it along with a context carrying it,
+	// which is bound to the request
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// A Span is the unit of work of a Tracer
+type Span interface {
+	// SetAttribute sets an attribute of the span, e.g. the status code
+	SetAttribute(key string, value interface{})
+
+	// RecordError records the error the call failed with
+	RecordError(err error)
+
+	// End completes the span
+	End()
+}
+
+// WithMetrics makes the client report its measurements to collector, see
+// MetricsCollector
+func WithMetrics(collector MetricsCollector) Option {
+	return func(c *Client) {
+		c.Metrics = collector
+	}
+}
+
+// WithTracer makes the client start a span around every request, see Tracer
+func WithTracer(tracer Tracer) Option {
+	return func(c *Client) {
+		c.Tracer = tracer
+	}
+}
+
+// instrument runs do, measuring it and tracing it as configured
+func (c *Client) instrument(req *http.Request, do func(*http.Request) (*Response, error)) (*Response, error) {
+	if c.Metrics == nil && c.Tracer == nil {
+		return do(req)
+	}
+
+	endpoint := c.endpoint(req)
+
+	var span Span
+	if c.Tracer != nil {
+		var ctx context.Context
+		ctx, span = c.Tracer.Start(req.Context(), "fanfou "+endpoint)
+		req = req.WithContext(ctx)
+
+		span.SetAttribute("http.request.method", req.Method)
+		span.SetAttribute("fanfou.endpoint", endpoint)
+	}
+
+	start := time.Now()
+	resp, err := do(req)
+	duration := time.Since(start)
+
+	statusCode := 0
+	var errResp *ErrorResponse
+	switch {
+	case resp != nil && resp.Response != nil:
+		statusCode = resp.Response.StatusCode
+	case errors.As(err, &errResp) && errResp.Response != nil:
+		statusCode = errResp.Response.StatusCode
+	}
+
+	if span != nil {
+		if statusCode != 0 {
+			span.SetAttribute("http.response.status_code", statusCode)
+		}
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}
+
+	if c.Metrics != nil {
+		c.Metrics.ObserveRequest(RequestMetrics{
+			Endpoint:   endpoint,
+			Method:     req.Method,
+			StatusCode: statusCode,
+			Duration:   duration,
+			Err:        err,
+		})
+
+		if c.RateLimiter != nil {
+			if remaining, ok := c.RateLimiter.remaining(c); ok {
+				c.Metrics.ObserveRateLimit(endpoint, remaining)
+			}
+		}
+	}
+
+	return resp, err
+}
+
+// Metrics is an in-memory MetricsCollector, keeping track of the latency
+// histograms, the error counts and the remaining rate limit budget of every
+// endpoint. It is safe for concurrent use.
+type Metrics struct {
+	// Buckets are the upper bounds of the latency histograms, in ascending
+	// order. DefaultLatencyBuckets is used if nil.
+	Buckets []time.Duration
+
+	mu        sync.Mutex
+	latencies map[string]*Histogram
+	errors    map[ErrorKey]int64
+	remaining map[string]int64
+}
+
+// Histogram is a latency histogram in the fashion of Prometheus
+type Histogram struct {
+	// Buckets are the upper bounds of the histogram
+	Buckets []time.Duration
+
+	// Counts are the cumulative counts of observations lower than or equal
+	// to the matching bucket
+	Counts []int64
+
+	// Count is the total count of observations
+	Count int64
+
+	// Sum is the sum of the observations
+	Sum time.Duration
+}
+
+// ErrorKey identifies a kind of failed requests counted by Metrics
+type ErrorKey struct {
+	// Endpoint is the API endpoint, e.g. "statuses/home_timeline"
+	Endpoint string
+
+	// StatusCode is the status code of the ErrorResponse, or 0 if the
+	// request failed without response
+	StatusCode int
+
+	// Message is the error message returned by Fanfou, if any
+	Message string
+}
+
+// NewMetrics returns an empty Metrics with the default latency buckets
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// ObserveRequest implements the MetricsCollector interface
+func (m *Metrics) ObserveRequest(r RequestMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.latencies == nil {
+		m.latencies = make(map[string]*Histogram)
+	}
+
+	h, ok := m.latencies[r.Endpoint]
+	if !ok {
+		buckets := m.Buckets
+		if buckets == nil {
+			buckets = DefaultLatencyBuckets
+		}
+		h = &Histogram{Buckets: buckets, Counts: make([]int64, len(buckets))}
+		m.latencies[r.Endpoint] = h
+	}
+
+	// Buckets are sorted, so every bucket from the first one matching
+	// counts the observation
+	for i := sort.Search(len(h.Buckets), func(i int) bool { return r.Duration <= h.Buckets[i] }); i < len(h.Buckets); i++ {
+		h.Counts[i]++
+	}
+	h.Count++
+	h.Sum += r.Duration
+
+	if r.Err == nil {
+		return
+	}
+
+	if m.errors == nil {
+		m.errors = make(map[ErrorKey]int64)
+	}
+
+	key := ErrorKey{Endpoint: r.Endpoint, StatusCode: r.StatusCode}
+	var errResp *ErrorResponse
+	if errors.As(r.Err, &errResp) && errResp.Meta != nil {
+		key.Message = errResp.Meta.Error
+	}
+	m.errors[key]++
+}
+
+// ObserveRateLimit implements the MetricsCollector interface
+func (m *Metrics) ObserveRateLimit(endpoint string, remaining int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.remaining == nil {
+		m.remaining = make(map[string]int64)
+	}
+	m.remaining[endpoint] = remaining
+}
+
+// Latency returns a copy of the latency histogram of the endpoint, and
+// whether any request to it was observed
+func (m *Metrics) Latency(endpoint string) (Histogram, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	h, ok := m.latencies[endpoint]
+	if !ok {
+		return Histogram{}, false
+	}
+
+	hCopy := *h
+	hCopy.Counts = append([]int64(nil), h.Counts...)
+
+	return hCopy, true
+}
+
+// Errors returns a copy of the error counts
+func (m *Metrics) Errors() map[ErrorKey]int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	errs := make(map[ErrorKey]int64, len(m.errors))
+	for key, count := range m.errors {
+		errs[key] = count
+	}
+
+	return errs
+}
+
+// RateLimitRemaining returns the remaining budget of API calls last
+// observed after a request to the endpoint, and whether it is known
+func (m *Metrics) RateLimitRemaining(endpoint string) (int64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	remaining, ok := m.remaining[endpoint]
+	return remaining, ok
+}
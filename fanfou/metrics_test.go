@@ -0,0 +1,185 @@
+package fanfou
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMetrics_observesRequests(t *testing.T) {
+	setup()
+	defer teardown()
+
+	reset := time.Now().Add(time.Hour)
+	mux.HandleFunc("/account/rate_limit_status.json", func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprintf(w, `{"remaining_hits": 10, "hourly_limit": 150, "reset_time_in_seconds": %d}`, reset.Unix())
+		if err != nil {
+			t.Errorf("account.rate_limit_status mock server error: %+v", err)
+		}
+	})
+
+	mux.HandleFunc("/statuses/home_timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprint(w, `[{"id": "test_id"}]`)
+		if err != nil {
+			t.Errorf("statuses.home_timeline mock server error: %+v", err)
+		}
+	})
+
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := fmt.Fprint(w, `{"request": "/statuses/show.json", "error": "test_error"}`)
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	metrics := NewMetrics()
+	c := newTestClient("test", "test", WithMetrics(metrics), WithRateLimiter(NewRateLimiter()))
+	if err := c.AuthorizeClientWithAccessTokens("test", "test", nil); err != nil {
+		t.Fatalf("AuthorizeClientWithAccessTokens() returned error: %v", err)
+	}
+
+	if _, _, err := c.Statuses.HomeTimeline(nil); err != nil {
+		t.Fatalf("statuses.home_timeline returned error: %v", err)
+	}
+
+	if _, _, err := c.Statuses.Show("test_id", nil); err == nil {
+		t.Fatalf("statuses.show returned no error")
+	}
+
+	for _, endpoint := range []string{"account/rate_limit_status", "statuses/home_timeline", "statuses/show"} {
+		h, ok := metrics.Latency(endpoint)
+		if !ok {
+			t.Errorf("Metrics.Latency(%q) is unknown", endpoint)
+			continue
+		}
+
+		if h.Count != 1 || h.Counts[len(h.Counts)-1] != 1 {
+			t.Errorf("Metrics.Latency(%q) = %+v, want a single observation", endpoint, h)
+		}
+	}
+
+	wantErrors := map[ErrorKey]int64{
+		{Endpoint: "statuses/show", StatusCode: http.StatusNotFound, Message: "test_error"}: 1,
+	}
+	if errs := metrics.Errors(); !reflect.DeepEqual(errs, wantErrors) {
+		t.Errorf("Metrics.Errors returned %+v, want %+v", errs, wantErrors)
+	}
+
+	if remaining, ok := metrics.RateLimitRemaining("statuses/home_timeline"); !ok || remaining != 9 {
+		t.Errorf("Metrics.RateLimitRemaining returned %v, %v, want %v, %v", remaining, ok, 9, true)
+	}
+
+	if remaining, ok := metrics.RateLimitRemaining("statuses/show"); !ok || remaining != 8 {
+		t.Errorf("Metrics.RateLimitRemaining returned %v, %v, want %v, %v", remaining, ok, 8, true)
+	}
+}
+
+func TestMetrics_histogram(t *testing.T) {
+	metrics := &Metrics{Buckets: []time.Duration{time.Millisecond, time.Second}}
+
+	for _, d := range []time.Duration{time.Microsecond, time.Millisecond, 10 * time.Millisecond, time.Minute} {
+		metrics.ObserveRequest(RequestMetrics{Endpoint: "statuses/show", Duration: d})
+	}
+
+	h, _ := metrics.Latency("statuses/show")
+	want := Histogram{
+		Buckets: []time.Duration{time.Millisecond, time.Second},
+		Counts:  []int64{2, 3},
+		Count:   4,
+		Sum:     time.Minute + 11*time.Millisecond + time.Microsecond,
+	}
+	if !reflect.DeepEqual(h, want) {
+		t.Errorf("Metrics.Latency returned %+v, want %+v", h, want)
+	}
+}
+
+type testSpanKey struct{}
+
+type testSpan struct {
+	name  string
+	attrs map[string]interface{}
+	errs  []error
+	ended bool
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *testSpan) RecordError(err error)                      { s.errs = append(s.errs, err) }
+func (s *testSpan) End()                                       { s.ended = true }
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (tr *testTracer) Start(ctx context.Context, spanName string) (context.Context, Span) {
+	span := &testSpan{name: spanName, attrs: make(map[string]interface{})}
+	tr.spans = append(tr.spans, span)
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+func TestTracer_spans(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, err := fmt.Fprint(w, `{"request": "/statuses/show.json", "error": "test_error"}`)
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	tracer := &testTracer{}
+	var spanInContext interface{}
+
+	c := newTestClient("test", "test",
+		WithTracer(tracer),
+		WithMiddleware(Middleware{
+			BeforeRequest: func(req *http.Request) error {
+				spanInContext = req.Context().Value(testSpanKey{})
+				return nil
+			},
+		}),
+	)
+	if err := c.AuthorizeClientWithAccessTokens("test", "test", nil); err != nil {
+		t.Fatalf("AuthorizeClientWithAccessTokens() returned error: %v", err)
+	}
+
+	_, _, err := c.Statuses.Show("test_id", nil)
+	if err == nil {
+		t.Fatalf("statuses.show returned no error")
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("Tracer started %d spans, want %d", len(tracer.spans), 1)
+	}
+
+	span := tracer.spans[0]
+	if span.name != "fanfou statuses/show" {
+		t.Errorf("Span name = %v, want %v", span.name, "fanfou statuses/show")
+	}
+
+	wantAttrs := map[string]interface{}{
+		"http.request.method":       "GET",
+		"fanfou.endpoint":           "statuses/show",
+		"http.response.status_code": http.StatusForbidden,
+	}
+	if !reflect.DeepEqual(span.attrs, wantAttrs) {
+		t.Errorf("Span attributes = %+v, want %+v", span.attrs, wantAttrs)
+	}
+
+	if len(span.errs) != 1 || span.errs[0] != err {
+		t.Errorf("Span errors = %v, want [%v]", span.errs, err)
+	}
+
+	if !span.ended {
+		t.Errorf("Span was not ended")
+	}
+
+	if spanInContext != span {
+		t.Errorf("Request context carries span %v, want %v", spanInContext, span)
+	}
+}
@@ -0,0 +1,101 @@
+package fanfou
+
+import (
+	"net/http"
+	"time"
+)
+
+// A Middleware hooks into the traffic of a Client, e.g. to log requests,
+// add headers or collect metrics. Every hook is optional.
+//
+// The hooks are called around every attempt to send a request, so a
+// request retried by the RetryPolicy goes through them once per attempt.
+// With several middlewares, BeforeRequest hooks are called in the order
+// the middlewares were given and the other hooks in the reverse order.
+//
+// Hooks may be called concurrently by multiple goroutines.
+type Middleware struct {
+	// BeforeRequest is called right before the request is signed and sent,
+	// and may alter it. Returning an error aborts the request.
+	BeforeRequest func(req *http.Request) error
+
+	// AfterResponse is called once a response is received, before it is
+	// checked for API errors. Returning an error fails the request.
+	AfterResponse func(req *http.Request, resp *http.Response) error
+
+	// OnError is called when the attempt failed with a network or an API
+	// error, and returns the error reported to the caller, usually err
+	// itself. The error cannot be swallowed: returning nil keeps err.
+	OnError func(req *http.Request, err error) error
+}
+
+// WithMiddleware appends middlewares to the chain of the client
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// roundTrip sends the request once through the middleware chain, and
+// checks the response for API errors
+func (c *Client) roundTrip(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	resp, err := c.exchange(httpClient, req)
+
+	if err != nil {
+		for i := len(c.middlewares) - 1; i >= 0; i-- {
+			if hook := c.middlewares[i].OnError; hook != nil {
+				if hookErr := hook(req, err); hookErr != nil {
+					err = hookErr
+				}
+			}
+		}
+	}
+
+	return resp, err
+}
+
+// exchange runs the BeforeRequest and AfterResponse hooks around sending
+// the request. The response is returned along with API errors, but not
+// along with errors of the hooks.
+func (c *Client) exchange(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	for _, m := range c.middlewares {
+		if m.BeforeRequest != nil {
+			if err := m.BeforeRequest(req); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	c.traceRequest("fanfou request", req)
+	start := time.Now()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		c.logDebug(req.Context(), "fanfou request failed", "url", redactURL(c.endpoint(req), req.URL), "error", err)
+		return nil, err
+	}
+
+	if c.logger != nil {
+		c.logDebug(req.Context(), "fanfou response",
+			"url", redactURL(c.endpoint(req), req.URL),
+			"status", resp.StatusCode,
+			"duration", time.Since(start))
+	}
+
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		if hook := c.middlewares[i].AfterResponse; hook != nil {
+			if err := hook(req, resp); err != nil {
+				_ = resp.Body.Close()
+				return nil, err
+			}
+		}
+	}
+
+	err = CheckResponse(resp)
+	if err != nil {
+		// Nothing more is read from the body of a failed response
+		_ = resp.Body.Close()
+	}
+
+	return resp, err
+}
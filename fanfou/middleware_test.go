@@ -0,0 +1,174 @@
+package fanfou
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMiddleware_hooks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Request-ID"); got != "test_request_id" {
+			t.Errorf("Request header X-Request-ID = %v, want %v", got, "test_request_id")
+		}
+		_, err := fmt.Fprint(w, `{"id": "test_id"}`)
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	var calls []string
+	middleware := func(name string) Middleware {
+		return Middleware{
+			BeforeRequest: func(req *http.Request) error {
+				calls = append(calls, name+".before")
+				req.Header.Set("X-Request-ID", "test_request_id")
+				return nil
+			},
+			AfterResponse: func(req *http.Request, resp *http.Response) error {
+				calls = append(calls, fmt.Sprintf("%s.after %d", name, resp.StatusCode))
+				return nil
+			},
+			OnError: func(req *http.Request, err error) error {
+				calls = append(calls, name+".error")
+				return err
+			},
+		}
+	}
+
+	c := newTestClient("test", "test", WithMiddleware(middleware("a"), middleware("b")))
+	if err := c.AuthorizeClientWithAccessTokens("test", "test", nil); err != nil {
+		t.Fatalf("AuthorizeClientWithAccessTokens() returned error: %v", err)
+	}
+
+	if _, _, err := c.Statuses.Show("test_id", nil); err != nil {
+		t.Fatalf("statuses.show returned error: %v", err)
+	}
+
+	want := []string{"a.before", "b.before", "b.after 200", "a.after 200"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Middleware hooks called %v, want %v", calls, want)
+	}
+}
+
+func TestMiddleware_onError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	wantErr := errors.New("test_error")
+	var seen []error
+
+	c := newTestClient("test", "test",
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}),
+		WithMiddleware(Middleware{
+			OnError: func(req *http.Request, err error) error {
+				seen = append(seen, err)
+				return wantErr
+			},
+		}),
+	)
+	if err := c.AuthorizeClientWithAccessTokens("test", "test", nil); err != nil {
+		t.Fatalf("AuthorizeClientWithAccessTokens() returned error: %v", err)
+	}
+
+	_, _, err := c.Statuses.Show("test_id", nil)
+	if err != wantErr {
+		t.Errorf("statuses.show returned %v, want %v", err, wantErr)
+	}
+
+	// Every attempt goes through the middlewares
+	if len(seen) != 2 || calls != 2 {
+		t.Fatalf("OnError was called %d times for %d attempts, want %d", len(seen), calls, 2)
+	}
+
+	if _, ok := seen[0].(*ErrorResponse); !ok {
+		t.Errorf("OnError was called with %v, want ErrorResponse", seen[0])
+	}
+}
+
+func TestMiddleware_onErrorCannotSwallow(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": "not found"}`)
+	})
+
+	// A server which is closed, to fail with a network error
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closedURL, _ := url.Parse(closed.URL)
+	closed.Close()
+
+	swallow := WithMiddleware(Middleware{
+		OnError: func(req *http.Request, err error) error {
+			return nil
+		},
+	})
+
+	tests := []struct {
+		name string
+		opts []Option
+		want func(error) bool
+	}{
+		{"API error", nil, func(err error) bool { return errors.Is(err, ErrNotFound) }},
+		{"network error", []Option{WithBaseURL(closedURL)}, func(err error) bool { return errors.As(err, new(*url.Error)) }},
+	}
+
+	for _, tt := range tests {
+		c := newTestClient("test", "test", append(tt.opts, swallow)...)
+		if err := c.AuthorizeClientWithAccessTokens("test", "test", nil); err != nil {
+			t.Fatalf("AuthorizeClientWithAccessTokens() returned error: %v", err)
+		}
+
+		if _, _, err := c.Statuses.Show("test_id", nil); !tt.want(err) {
+			t.Errorf("statuses.show with a swallowed %s returned %v, want the original error", tt.name, err)
+		}
+	}
+}
+
+func TestMiddleware_beforeRequestAborts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+
+	wantErr := errors.New("test_error")
+	c := newTestClient("test", "test",
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}),
+		WithMiddleware(Middleware{
+			BeforeRequest: func(req *http.Request) error {
+				return wantErr
+			},
+		}),
+	)
+	if err := c.AuthorizeClientWithAccessTokens("test", "test", nil); err != nil {
+		t.Fatalf("AuthorizeClientWithAccessTokens() returned error: %v", err)
+	}
+
+	_, _, err := c.Statuses.Show("test_id", nil)
+	if err != wantErr {
+		t.Errorf("statuses.show returned %v, want %v", err, wantErr)
+	}
+
+	if calls != 0 {
+		t.Errorf("statuses.show was sent %d times, want %d", calls, 0)
+	}
+}
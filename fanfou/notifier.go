@@ -0,0 +1,288 @@
+package fanfou
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultNotifyInterval is the default interval between the polls of a
+// Notifier
+const DefaultNotifyInterval = 30 * time.Second
+
+// NewMention is the event of a new status mentioning the current user
+type NewMention struct {
+	Status StatusResult
+}
+
+// NewDM is the event of a new direct message to the current user
+type NewDM struct {
+	Message DirectMessageResult
+}
+
+// NewFriendRequest is the event of a new request to follow the current user
+type NewFriendRequest struct {
+	User UserResult
+}
+
+// NotifierOptParams specifies the optional params for notifiers
+type NotifierOptParams struct {
+	// Interval is the interval between the polls of account/notification,
+	// DefaultNotifyInterval if not set
+	Interval time.Duration
+
+	// OnError is called with the errors of the polls, which do not stop
+	// the notifier
+	OnError func(error)
+}
+
+// A Notifier polls the unread counts of account/notification and, when
+// they increase, fetches the new mentions, direct messages and friend
+// requests and passes them to the registered handlers. The mentions and
+// direct messages are fetched since the last ones raised, so none is raised
+// twice.
+//
+// Items read elsewhere between two polls may not increase the counts, and
+// are then not raised.
+type Notifier struct {
+	client *Client
+	opt    NotifierOptParams
+
+	mu              sync.Mutex
+	mentionHandlers []func(context.Context, NewMention)
+	dmHandlers      []func(context.Context, NewDM)
+	requestHandlers []func(context.Context, NewFriendRequest)
+
+	counts         NotificationResult
+	mentionSinceID string
+	dmSinceID      string
+	requestsSeen   map[string]bool
+	primed         bool
+}
+
+// NewNotifier returns a Notifier polling with the client
+func NewNotifier(c *Client, opt *NotifierOptParams) *Notifier {
+	n := &Notifier{client: c, requestsSeen: make(map[string]bool)}
+	if opt != nil {
+		n.opt = *opt
+	}
+	if n.opt.Interval <= 0 {
+		n.opt.Interval = DefaultNotifyInterval
+	}
+
+	return n
+}
+
+// OnMention registers a handler of the NewMention events
+func (n *Notifier) OnMention(h func(context.Context, NewMention)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.mentionHandlers = append(n.mentionHandlers, h)
+}
+
+// OnDM registers a handler of the NewDM events
+func (n *Notifier) OnDM(h func(context.Context, NewDM)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.dmHandlers = append(n.dmHandlers, h)
+}
+
+// OnFriendRequest registers a handler of the NewFriendRequest events
+func (n *Notifier) OnFriendRequest(h func(context.Context, NewFriendRequest)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.requestHandlers = append(n.requestHandlers, h)
+}
+
+// Run polls until ctx is done, then returns ctx.Err(). The handlers are
+// called from Run, in chronological order of the events. The mentions,
+// direct messages and friend requests already there when Run is first
+// called do not raise events.
+func (n *Notifier) Run(ctx context.Context) error {
+	ticker := time.NewTicker(n.opt.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := n.poll(ctx); err != nil && ctx.Err() == nil && n.opt.OnError != nil {
+			n.opt.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll fetches the unread counts, and the new items of the counts which
+// increased since the last poll
+func (n *Notifier) poll(ctx context.Context) error {
+	if !n.primed {
+		err := n.prime(ctx)
+		n.primed = err == nil
+		return err
+	}
+
+	counts, _, err := n.client.Account.NotificationContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if counts.Mentions > n.counts.Mentions {
+		if err := n.fetchMentions(ctx); err != nil {
+			return err
+		}
+	}
+	n.counts.Mentions = counts.Mentions
+
+	if counts.DirectMessages > n.counts.DirectMessages {
+		if err := n.fetchDMs(ctx); err != nil {
+			return err
+		}
+	}
+	n.counts.DirectMessages = counts.DirectMessages
+
+	if counts.FriendRequests > n.counts.FriendRequests {
+		if err := n.fetchRequests(ctx); err != nil {
+			return err
+		}
+	}
+	n.counts.FriendRequests = counts.FriendRequests
+
+	return nil
+}
+
+// prime records the unread counts, the newest mention and direct message,
+// and the pending friend requests, which are not new
+func (n *Notifier) prime(ctx context.Context) error {
+	counts, _, err := n.client.Account.NotificationContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	mentions, _, err := n.client.Statuses.MentionsContext(ctx, &StatusesOptParams{Count: 1})
+	if err != nil {
+		return err
+	}
+
+	messages, _, err := n.client.DirectMessages.InboxContext(ctx, &DirectMessagesOptParams{Count: 1})
+	if err != nil {
+		return err
+	}
+
+	requests, _, err := n.client.Friendships.RequestsContext(ctx, &FriendshipsOptParams{Count: DefaultPageCount})
+	if err != nil {
+		return err
+	}
+
+	n.counts = *counts
+	if len(mentions) > 0 {
+		n.mentionSinceID = mentions[0].ID
+	}
+	if len(messages) > 0 {
+		n.dmSinceID = messages[0].ID
+	}
+	for _, user := range requests {
+		n.requestsSeen[user.ID] = true
+	}
+
+	return nil
+}
+
+// fetchSince fetches the items newer than the last one seen, or all of them
+// if none was seen, newest first, fetch being bound to the since_id. See
+// fillGap.
+func fetchSince[T timelineItem](ctx context.Context, fetch func(ctx context.Context, maxID string) ([]T, error)) ([]T, error) {
+	page, err := fetch(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return fillGap(ctx, page, DefaultPageCount, fetch)
+}
+
+// fetchMentions fetches the mentions newer than the last one seen, and
+// raises their events
+func (n *Notifier) fetchMentions(ctx context.Context) error {
+	mentions, err := fetchSince(ctx, func(ctx context.Context, maxID string) ([]StatusResult, error) {
+		mentions, _, err := n.client.Statuses.MentionsContext(ctx, &StatusesOptParams{SinceID: n.mentionSinceID, MaxID: maxID, Count: DefaultPageCount})
+		return mentions, err
+	})
+	if err != nil || len(mentions) == 0 {
+		return err
+	}
+
+	n.mentionSinceID = mentions[0].ID
+
+	n.mu.Lock()
+	handlers := n.mentionHandlers
+	n.mu.Unlock()
+
+	slices.Reverse(mentions)
+	for _, status := range mentions {
+		for _, h := range handlers {
+			h(ctx, NewMention{Status: status})
+		}
+	}
+
+	return nil
+}
+
+// fetchDMs fetches the direct messages newer than the last one seen, and
+// raises their events
+func (n *Notifier) fetchDMs(ctx context.Context) error {
+	messages, err := fetchSince(ctx, func(ctx context.Context, maxID string) ([]DirectMessageResult, error) {
+		messages, _, err := n.client.DirectMessages.InboxContext(ctx, &DirectMessagesOptParams{SinceID: n.dmSinceID, MaxID: maxID, Count: DefaultPageCount})
+		return messages, err
+	})
+	if err != nil || len(messages) == 0 {
+		return err
+	}
+
+	n.dmSinceID = messages[0].ID
+
+	n.mu.Lock()
+	handlers := n.dmHandlers
+	n.mu.Unlock()
+
+	slices.Reverse(messages)
+	for _, message := range messages {
+		for _, h := range handlers {
+			h(ctx, NewDM{Message: message})
+		}
+	}
+
+	return nil
+}
+
+// fetchRequests fetches the pending friend requests, and raises the events
+// of the ones not seen yet
+func (n *Notifier) fetchRequests(ctx context.Context) error {
+	requests, _, err := n.client.Friendships.RequestsContext(ctx, &FriendshipsOptParams{Count: DefaultPageCount})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	handlers := n.requestHandlers
+	n.mu.Unlock()
+
+	slices.Reverse(requests)
+	for _, user := range requests {
+		if n.requestsSeen[user.ID] {
+			continue
+		}
+		n.requestsSeen[user.ID] = true
+
+		for _, h := range handlers {
+			h(ctx, NewFriendRequest{User: user})
+		}
+	}
+
+	return nil
+}
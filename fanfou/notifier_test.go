@@ -0,0 +1,154 @@
+package fanfou
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNotifier(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mentions := &testTimeline{n: 1}
+	messages := &testTimeline{n: 1}
+	requests := &testTimeline{n: 1}
+
+	var mu sync.Mutex
+	notification := `{"mentions": 1, "direct_messages": 0, "friend_requests": 1}`
+
+	polls := 0
+	mux.HandleFunc("/account/notification.json", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+		fmt.Fprint(w, notification)
+	})
+	mux.Handle("/statuses/mentions.json", mentions)
+	mux.Handle("/direct_messages/inbox.json", messages)
+	mux.Handle("/friendships/requests.json", requests)
+
+	pollCount := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return polls
+	}
+
+	// The handlers are called from the polls, so the events of a poll are
+	// all raised once the next poll starts
+	waitPolls := func(count int) {
+		target := pollCount() + count
+		deadline := time.Now().Add(time.Second)
+		for pollCount() < target {
+			if time.Now().After(deadline) {
+				t.Fatalf("notifier did not poll %d times", count)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	var eventsMu sync.Mutex
+	events := make(map[string][]string)
+	raise := func(kind, id string) {
+		eventsMu.Lock()
+		defer eventsMu.Unlock()
+		events[kind] = append(events[kind], id)
+	}
+	raised := func() map[string][]string {
+		eventsMu.Lock()
+		defer eventsMu.Unlock()
+		return copyEvents(events)
+	}
+
+	errs := make(chan error, 10)
+
+	n := NewNotifier(client, &NotifierOptParams{
+		Interval: time.Millisecond,
+		OnError:  func(err error) { errs <- err },
+	})
+	n.OnMention(func(ctx context.Context, e NewMention) { raise("mention", e.Status.ID) })
+	n.OnDM(func(ctx context.Context, e NewDM) { raise("dm", e.Message.ID) })
+	n.OnFriendRequest(func(ctx context.Context, e NewFriendRequest) { raise("request", e.User.ID) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error)
+	go func() { done <- n.Run(ctx) }()
+
+	// The items already there when the notifier starts do not raise events
+	waitPolls(3)
+	if got := raised(); len(got) != 0 {
+		t.Fatalf("notifier raised %v before anything new arrived", got)
+	}
+
+	// More direct messages than a page holds
+	var newMessages []string
+	for id := 2; id <= DefaultPageCount+6; id++ {
+		newMessages = append(newMessages, strconv.Itoa(id))
+	}
+
+	// Nothing is fetched until the counts increase
+	waitPolls(2)
+	served := []int{mentions.served(), messages.served(), requests.served()}
+
+	mentions.post(2)
+	messages.post(len(newMessages))
+	requests.post(1)
+
+	waitPolls(2)
+	if got := []int{mentions.served(), messages.served(), requests.served()}; !reflect.DeepEqual(got, served) {
+		t.Errorf("notifier fetched %v pages while the counts did not increase, want %v", got, served)
+	}
+	if got := raised(); len(got) != 0 {
+		t.Fatalf("notifier raised %v while the counts did not increase", got)
+	}
+
+	mu.Lock()
+	notification = fmt.Sprintf(`{"mentions": 3, "direct_messages": %d, "friend_requests": 2}`, len(newMessages))
+	mu.Unlock()
+
+	// Every item is raised by the first poll which sees its count increase
+	waitPolls(2)
+
+	want := map[string][]string{
+		"mention": {"2", "3"},
+		"dm":      newMessages,
+		"request": {"2"},
+	}
+	if got := raised(); !reflect.DeepEqual(got, want) {
+		t.Errorf("notifier raised %v, want %v", got, want)
+	}
+
+	waitPolls(2)
+	if got := raised(); !reflect.DeepEqual(got, want) {
+		t.Errorf("notifier raised %v while nothing new arrived, want %v", got, want)
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("notifier.Run returned %v, want %v", err, context.Canceled)
+	}
+
+	select {
+	case err := <-errs:
+		t.Errorf("notifier reported error: %v", err)
+	default:
+	}
+}
+
+// copyEvents returns a deep copy of the events
+func copyEvents(events map[string][]string) map[string][]string {
+	c := make(map[string][]string, len(events))
+	for kind, ids := range events {
+		c[kind] = slices.Clone(ids)
+	}
+
+	return c
+}
@@ -0,0 +1,96 @@
+package fanfou
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// An Option configures a Client, see NewClient
+type Option func(*Client)
+
+// WithHTTPClient makes the client send its requests with httpClient, e.g.
+// to set a timeout. The transport of httpClient is used to send the
+// requests once they are signed.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.baseClient = httpClient
+	}
+}
+
+// WithTransport makes the client send its requests with transport, e.g. to
+// go through a proxy or use a custom TLS config
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		httpClient := &http.Client{}
+		if c.baseClient != nil {
+			*httpClient = *c.baseClient
+		}
+
+		httpClient.Transport = transport
+		c.baseClient = httpClient
+	}
+}
+
+// WithBaseURL makes the client send its API requests to baseURL instead of
+// the official BaseURL. A nil baseURL is ignored.
+func WithBaseURL(baseURL *url.URL) Option {
+	return func(c *Client) {
+		if baseURL == nil {
+			return
+		}
+		c.BaseURL = withTrailingSlash(baseURL)
+	}
+}
+
+// WithAuthBaseURL makes the client send its authorization requests to
+// authBaseURL instead of the official AuthBaseURL. A nil authBaseURL is
+// ignored.
+func WithAuthBaseURL(authBaseURL *url.URL) Option {
+	return func(c *Client) {
+		if authBaseURL == nil {
+			return
+		}
+		c.authBaseURL = withTrailingSlash(authBaseURL)
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the API requests
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithDebug enables or disables the tracing of the traffic of the client.
+// The traces are written to stderr, unless a logger is set with WithLogger.
+func WithDebug(enabled bool) Option {
+	return func(c *Client) {
+		c.debug = enabled
+	}
+}
+
+// WithRetryPolicy sets the retry policy of the client, see RetryPolicy
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Client) {
+		c.RetryPolicy = policy
+	}
+}
+
+// WithRateLimiter sets the rate limiter of the client, see RateLimiter
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.RateLimiter = limiter
+	}
+}
+
+// withTrailingSlash returns a copy of u whose path ends with a slash, so
+// relative URIs are resolved below it
+func withTrailingSlash(u *url.URL) *url.URL {
+	newURL := *u
+	if !strings.HasSuffix(newURL.Path, "/") {
+		newURL.Path += "/"
+	}
+
+	return &newURL
+}
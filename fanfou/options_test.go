@@ -0,0 +1,106 @@
+package fanfou
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestNewClient_options(t *testing.T) {
+	baseURL, _ := url.Parse("https://api.example.com/v1")
+	authBaseURL, _ := url.Parse("https://auth.example.com/")
+	transport := &http.Transport{}
+	policy := DefaultRetryPolicy()
+	limiter := NewRateLimiter()
+
+	c := NewClient("", "",
+		WithHTTPClient(&http.Client{Timeout: time.Second}),
+		WithTransport(transport),
+		WithBaseURL(baseURL),
+		WithAuthBaseURL(authBaseURL),
+		WithUserAgent("test-agent"),
+		WithRetryPolicy(policy),
+		WithRateLimiter(limiter),
+	)
+
+	if want := "https://api.example.com/v1/"; c.BaseURL.String() != want {
+		t.Errorf("NewClient BaseURL = %v, want %v", c.BaseURL, want)
+	}
+
+	if want := "https://auth.example.com/oauth/request_token"; c.authURL(requestTokenURI) != want {
+		t.Errorf("NewClient request token URL = %v, want %v", c.authURL(requestTokenURI), want)
+	}
+
+	if c.UserAgent != "test-agent" {
+		t.Errorf("NewClient UserAgent = %v, want %v", c.UserAgent, "test-agent")
+	}
+
+	if c.baseClient.Timeout != time.Second || c.baseClient.Transport != transport {
+		t.Errorf("NewClient HTTP client = %+v, want timeout %v and the given transport", c.baseClient, time.Second)
+	}
+
+	if c.oauthConsumer.HttpClient != c.baseClient {
+		t.Errorf("NewClient OAuth consumer HTTP client = %v, want %v", c.oauthConsumer.HttpClient, c.baseClient)
+	}
+
+	if c.RetryPolicy != policy {
+		t.Errorf("NewClient RetryPolicy = %v, want %v", c.RetryPolicy, policy)
+	}
+
+	if c.RateLimiter != limiter {
+		t.Errorf("NewClient RateLimiter = %v, want %v", c.RateLimiter, limiter)
+	}
+
+	// The package globals are left untouched
+	if BaseURL != "https://api.fanfou.com/" || AuthBaseURL != "https://fanfou.com/" {
+		t.Errorf("NewClient changed BaseURL to %v and AuthBaseURL to %v", BaseURL, AuthBaseURL)
+	}
+}
+
+func TestNewClient_nilURLs(t *testing.T) {
+	c := NewClient("", "", WithBaseURL(nil), WithAuthBaseURL(nil))
+
+	if c.BaseURL.String() != BaseURL {
+		t.Errorf("NewClient BaseURL = %v, want %v", c.BaseURL, BaseURL)
+	}
+
+	if want := AuthBaseURL + requestTokenURI; c.authURL(requestTokenURI) != want {
+		t.Errorf("NewClient request token URL = %v, want %v", c.authURL(requestTokenURI), want)
+	}
+}
+
+func TestNewClient_withTransport(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/foo/bar.json", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Test"); got != "test" {
+			t.Errorf("Request header X-Test = %v, want %v", got, "test")
+		}
+	})
+
+	c := newTestClient("test", "test", WithTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req.Header.Set("X-Test", "test")
+		return http.DefaultTransport.RoundTrip(req)
+	})))
+
+	if err := c.AuthorizeClientWithAccessTokens("test", "test", nil); err != nil {
+		t.Fatalf("AuthorizeClientWithAccessTokens() returned error: %v", err)
+	}
+
+	req, err := c.NewRequest(http.MethodGet, "foo/bar.json", "")
+	if err != nil {
+		t.Fatalf("NewRequest() returned error: %v", err)
+	}
+
+	if _, err := c.Do(req, nil); err != nil {
+		t.Errorf("Do() returned error: %v", err)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,330 @@
+package fanfou
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// DefaultPageCount is the count of items requested per page by the page
+// iterators when the count is not specified, which is also the maximum
+// count of items Fanfou returns per page
+const DefaultPageCount = 60
+
+// PageOptParams specifies how the pages of a list are fetched by the page
+// iterators
+type PageOptParams struct {
+	// Concurrency is the number of pages fetched at once, 1 if not set.
+	// As the end of the list is only known once its last page is fetched,
+	// up to Concurrency-1 requests past it may be sent.
+	Concurrency int
+
+	// Limit is the maximum number of items yielded in total, 0 for no limit
+	Limit int
+}
+
+// pageResult is the result of the fetch of a page
+type pageResult[T any] struct {
+	items []T
+	err   error
+}
+
+// paginatePages walks a list paged by page number, from the first page up
+// to the first page shorter than count. Items already yielded, which show
+// up again when the list changes while it is walked, are skipped.
+func paginatePages[T any](ctx context.Context, opt *PageOptParams, count int64, key func(*T) string, fetch func(ctx context.Context, page int64) ([]T, error)) iter.Seq2[T, error] {
+	if opt == nil {
+		opt = &PageOptParams{}
+	}
+
+	workers := max(opt.Concurrency, 1)
+
+	return func(yield func(T, error) bool) {
+		seen := make(map[string]bool)
+		yielded := 0
+
+		for first := int64(1); ; first += int64(workers) {
+			results := make([]pageResult[T], workers)
+
+			var wg sync.WaitGroup
+			for i := range results {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					results[i].items, results[i].err = fetch(ctx, first+int64(i))
+				}()
+			}
+			wg.Wait()
+
+			for _, result := range results {
+				if result.err != nil {
+					var zero T
+					yield(zero, result.err)
+					return
+				}
+
+				for i := range result.items {
+					k := key(&result.items[i])
+					if seen[k] {
+						continue
+					}
+					seen[k] = true
+
+					if !yield(result.items[i], nil) {
+						return
+					}
+
+					yielded++
+					if opt.Limit > 0 && yielded >= opt.Limit {
+						return
+					}
+				}
+
+				if int64(len(result.items)) < count {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Collect returns the items of seq, or the first error it yields
+//
+//	ids, err := fanfou.Collect(c.Followers.IDsIter(nil, nil))
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// pageCount returns count, or DefaultPageCount if count is not set or
+// larger, so that a full page is never taken for the last one
+func pageCount(count int64) int64 {
+	if count > 0 && count < DefaultPageCount {
+		return count
+	}
+
+	return DefaultPageCount
+}
+
+// userIDKey returns the user ID itself, for deduplication
+func userIDKey(id *string) string {
+	return *id
+}
+
+// userKey returns the ID of the user, for deduplication
+func userKey(user *UserResult) string {
+	return user.ID
+}
+
+// statusKey returns the ID of the status, for deduplication
+func statusKey(status *StatusResult) string {
+	return status.ID
+}
+
+// paged returns a copy of opt fetching the given page
+func (opt *FollowersOptParams) paged(page int64) *FollowersOptParams {
+	p := FollowersOptParams{}
+	if opt != nil {
+		p = *opt
+	}
+	p.Page, p.Count = page, pageCount(p.Count)
+
+	return &p
+}
+
+// paged returns a copy of opt fetching the given page
+func (opt *FriendsOptParams) paged(page int64) *FriendsOptParams {
+	p := FriendsOptParams{}
+	if opt != nil {
+		p = *opt
+	}
+	p.Page, p.Count = page, pageCount(p.Count)
+
+	return &p
+}
+
+// paged returns a copy of opt fetching the given page
+func (opt *UsersOptParams) paged(page int64) *UsersOptParams {
+	p := UsersOptParams{}
+	if opt != nil {
+		p = *opt
+	}
+	p.Page, p.Count = page, pageCount(p.Count)
+
+	return &p
+}
+
+// paged returns a copy of opt fetching the given page
+func (opt *BlocksOptParams) paged(page int64) *BlocksOptParams {
+	p := BlocksOptParams{}
+	if opt != nil {
+		p = *opt
+	}
+	p.Page, p.Count = page, pageCount(p.Count)
+
+	return &p
+}
+
+// paged returns a copy of opt fetching the given page
+func (opt *FriendshipsOptParams) paged(page int64) *FriendshipsOptParams {
+	p := FriendshipsOptParams{}
+	if opt != nil {
+		p = *opt
+	}
+	p.Page, p.Count = page, pageCount(p.Count)
+
+	return &p
+}
+
+// paged returns a copy of opt fetching the given page
+func (opt *FavoritesOptParams) paged(page int64) *FavoritesOptParams {
+	p := FavoritesOptParams{}
+	if opt != nil {
+		p = *opt
+	}
+	p.Page, p.Count = page, pageCount(p.Count)
+
+	return &p
+}
+
+// IDsIter shall iterate over the follower IDs of the specified user, or of
+// the current user if no ID specified, fetching every page until the last
+// one. opt.Page is ignored, and popt specifies how the pages are fetched.
+// An error ends the iteration, after being yielded.
+//
+//	for id, err := range c.Followers.IDsIter(nil, &fanfou.PageOptParams{Concurrency: 4}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(id)
+//	}
+func (s *FollowersService) IDsIter(opt *FollowersOptParams, popt *PageOptParams) iter.Seq2[string, error] {
+	return s.IDsIterContext(context.Background(), opt, popt)
+}
+
+// IDsIterContext is the same as IDsIter, except that the requests are
+// bound to ctx and aborted once ctx is done
+func (s *FollowersService) IDsIterContext(ctx context.Context, opt *FollowersOptParams, popt *PageOptParams) iter.Seq2[string, error] {
+	return paginatePages(ctx, popt, opt.paged(0).Count, userIDKey, func(ctx context.Context, page int64) ([]string, error) {
+		ids, _, err := s.IDsContext(ctx, opt.paged(page))
+		if err != nil {
+			return nil, err
+		}
+		return *ids, nil
+	})
+}
+
+// IDsIter shall iterate over the friend IDs of the specified user, or of
+// the current user if no ID specified, see FollowersService.IDsIter
+func (s *FriendsService) IDsIter(opt *FriendsOptParams, popt *PageOptParams) iter.Seq2[string, error] {
+	return s.IDsIterContext(context.Background(), opt, popt)
+}
+
+// IDsIterContext is the same as IDsIter, except that the requests are
+// bound to ctx and aborted once ctx is done
+func (s *FriendsService) IDsIterContext(ctx context.Context, opt *FriendsOptParams, popt *PageOptParams) iter.Seq2[string, error] {
+	return paginatePages(ctx, popt, opt.paged(0).Count, userIDKey, func(ctx context.Context, page int64) ([]string, error) {
+		ids, _, err := s.IDsContext(ctx, opt.paged(page))
+		if err != nil {
+			return nil, err
+		}
+		return *ids, nil
+	})
+}
+
+// FollowersIter shall iterate over the followers of the specified user, or
+// of the current user if no ID specified, see FollowersService.IDsIter
+func (s *UsersService) FollowersIter(opt *UsersOptParams, popt *PageOptParams) iter.Seq2[UserResult, error] {
+	return s.FollowersIterContext(context.Background(), opt, popt)
+}
+
+// FollowersIterContext is the same as FollowersIter, except that the
+// requests are bound to ctx and aborted once ctx is done
+func (s *UsersService) FollowersIterContext(ctx context.Context, opt *UsersOptParams, popt *PageOptParams) iter.Seq2[UserResult, error] {
+	return paginatePages(ctx, popt, opt.paged(0).Count, userKey, func(ctx context.Context, page int64) ([]UserResult, error) {
+		users, _, err := s.FollowersContext(ctx, opt.paged(page))
+		return users, err
+	})
+}
+
+// FriendsIter shall iterate over the friends of the specified user, or of
+// the current user if no ID specified, see FollowersService.IDsIter
+func (s *UsersService) FriendsIter(opt *UsersOptParams, popt *PageOptParams) iter.Seq2[UserResult, error] {
+	return s.FriendsIterContext(context.Background(), opt, popt)
+}
+
+// FriendsIterContext is the same as FriendsIter, except that the requests
+// are bound to ctx and aborted once ctx is done
+func (s *UsersService) FriendsIterContext(ctx context.Context, opt *UsersOptParams, popt *PageOptParams) iter.Seq2[UserResult, error] {
+	return paginatePages(ctx, popt, opt.paged(0).Count, userKey, func(ctx context.Context, page int64) ([]UserResult, error) {
+		users, _, err := s.FriendsContext(ctx, opt.paged(page))
+		return users, err
+	})
+}
+
+// TaggedIter shall iterate over the users tagged with Tag, see
+// FollowersService.IDsIter
+func (s *UsersService) TaggedIter(Tag string, opt *UsersOptParams, popt *PageOptParams) iter.Seq2[UserResult, error] {
+	return s.TaggedIterContext(context.Background(), Tag, opt, popt)
+}
+
+// TaggedIterContext is the same as TaggedIter, except that the requests
+// are bound to ctx and aborted once ctx is done
+func (s *UsersService) TaggedIterContext(ctx context.Context, Tag string, opt *UsersOptParams, popt *PageOptParams) iter.Seq2[UserResult, error] {
+	return paginatePages(ctx, popt, opt.paged(0).Count, userKey, func(ctx context.Context, page int64) ([]UserResult, error) {
+		users, _, err := s.TaggedContext(ctx, Tag, opt.paged(page))
+		return users, err
+	})
+}
+
+// BlockingIter shall iterate over the blocked users, see
+// FollowersService.IDsIter
+func (s *BlocksService) BlockingIter(opt *BlocksOptParams, popt *PageOptParams) iter.Seq2[UserResult, error] {
+	return s.BlockingIterContext(context.Background(), opt, popt)
+}
+
+// BlockingIterContext is the same as BlockingIter, except that the
+// requests are bound to ctx and aborted once ctx is done
+func (s *BlocksService) BlockingIterContext(ctx context.Context, opt *BlocksOptParams, popt *PageOptParams) iter.Seq2[UserResult, error] {
+	return paginatePages(ctx, popt, opt.paged(0).Count, userKey, func(ctx context.Context, page int64) ([]UserResult, error) {
+		users, _, err := s.BlockingContext(ctx, opt.paged(page))
+		return users, err
+	})
+}
+
+// RequestsIter shall iterate over the users requesting to follow the
+// current user, see FollowersService.IDsIter
+func (s *FriendshipsService) RequestsIter(opt *FriendshipsOptParams, popt *PageOptParams) iter.Seq2[UserResult, error] {
+	return s.RequestsIterContext(context.Background(), opt, popt)
+}
+
+// RequestsIterContext is the same as RequestsIter, except that the
+// requests are bound to ctx and aborted once ctx is done
+func (s *FriendshipsService) RequestsIterContext(ctx context.Context, opt *FriendshipsOptParams, popt *PageOptParams) iter.Seq2[UserResult, error] {
+	return paginatePages(ctx, popt, opt.paged(0).Count, userKey, func(ctx context.Context, page int64) ([]UserResult, error) {
+		users, _, err := s.RequestsContext(ctx, opt.paged(page))
+		return users, err
+	})
+}
+
+// IDsIter shall iterate over the favorites of the specified user, or of the
+// current user if no ID specified, see FollowersService.IDsIter
+func (s *FavoritesService) IDsIter(opt *FavoritesOptParams, popt *PageOptParams) iter.Seq2[StatusResult, error] {
+	return s.IDsIterContext(context.Background(), opt, popt)
+}
+
+// IDsIterContext is the same as IDsIter, except that the requests are
+// bound to ctx and aborted once ctx is done
+func (s *FavoritesService) IDsIterContext(ctx context.Context, opt *FavoritesOptParams, popt *PageOptParams) iter.Seq2[StatusResult, error] {
+	return paginatePages(ctx, popt, opt.paged(0).Count, statusKey, func(ctx context.Context, page int64) ([]StatusResult, error) {
+		statuses, _, err := s.IDsContext(ctx, opt.paged(page))
+		return statuses, err
+	})
+}
@@ -0,0 +1,168 @@
+package fanfou
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// serveTestIDs serves a list of n user IDs, from "1" to n, paged by page
+// number. Every page repeats the last ID of the previous page, as happens
+// when the list grows while it is walked.
+func serveTestIDs(t *testing.T, pattern string, n int) func() []string {
+	var mu sync.Mutex
+	var pages []string
+
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		page, _ := strconv.Atoi(r.FormValue("page"))
+		count, _ := strconv.Atoi(r.FormValue("count"))
+
+		mu.Lock()
+		pages = append(pages, r.FormValue("page"))
+		mu.Unlock()
+
+		ids := []string{}
+		for id := (page-1)*(count-1) + 1; id <= n && len(ids) < count; id++ {
+			ids = append(ids, strconv.Itoa(id))
+		}
+
+		if err := json.NewEncoder(w).Encode(ids); err != nil {
+			t.Errorf("%s mock server error: %+v", pattern, err)
+		}
+	})
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return pages
+	}
+}
+
+func TestFollowersService_IDsIter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pages := serveTestIDs(t, "/followers/ids.json", 7)
+
+	ids, err := Collect(client.Followers.IDsIter(&FollowersOptParams{Count: 3, Page: 5}, nil))
+	if err != nil {
+		t.Fatalf("followers.ids_iter returned error: %v", err)
+	}
+
+	want := []string{"1", "2", "3", "4", "5", "6", "7"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("followers.ids_iter returned %v, want %v", ids, want)
+	}
+
+	// The third page, [5 6 7], is full: the fourth one, [7], is the last
+	if wantPages := []string{"1", "2", "3", "4"}; !reflect.DeepEqual(pages(), wantPages) {
+		t.Errorf("followers.ids_iter requested pages %v, want %v", pages(), wantPages)
+	}
+}
+
+func TestFollowersService_IDsIterCountAboveMax(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Fanfou returns at most DefaultPageCount items per page, whatever the
+	// count requested
+	var pages []string
+	mux.HandleFunc("/followers/ids.json", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.FormValue("page"))
+		pages = append(pages, r.FormValue("page"))
+
+		ids := []string{}
+		for id := (page-1)*DefaultPageCount + 1; id <= 130 && len(ids) < DefaultPageCount; id++ {
+			ids = append(ids, strconv.Itoa(id))
+		}
+
+		if err := json.NewEncoder(w).Encode(ids); err != nil {
+			t.Errorf("followers.ids mock server error: %+v", err)
+		}
+	})
+
+	ids, err := Collect(client.Followers.IDsIter(&FollowersOptParams{Count: 100}, nil))
+	if err != nil {
+		t.Fatalf("followers.ids_iter returned error: %v", err)
+	}
+
+	if len(ids) != 130 {
+		t.Errorf("followers.ids_iter returned %d IDs, want %d", len(ids), 130)
+	}
+
+	if wantPages := []string{"1", "2", "3"}; !reflect.DeepEqual(pages, wantPages) {
+		t.Errorf("followers.ids_iter requested pages %v, want %v", pages, wantPages)
+	}
+}
+
+func TestFriendsService_IDsIterConcurrent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pages := serveTestIDs(t, "/friends/ids.json", 200)
+
+	ids, err := Collect(client.Friends.IDsIter(nil, &PageOptParams{Concurrency: 3}))
+	if err != nil {
+		t.Fatalf("friends.ids_iter returned error: %v", err)
+	}
+
+	if len(ids) != 200 || ids[0] != "1" || ids[199] != "200" {
+		t.Errorf("friends.ids_iter returned %d IDs from %v to %v, want 200 IDs in order", len(ids), ids[0], ids[len(ids)-1])
+	}
+
+	// Pages of 60 IDs, overlapping by one: the fourth page is the last one,
+	// fetched along with the fifth and sixth
+	if got := len(pages()); got != 6 {
+		t.Errorf("friends.ids_iter requested %d pages, want %d", got, 6)
+	}
+}
+
+func TestUsersService_FollowersIterLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/followers.json", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"page": "1", "count": "60"})
+		fmt.Fprint(w, `[{"id": "test_id_1"}, {"id": "test_id_2"}, {"id": "test_id_3"}]`)
+	})
+
+	users, err := Collect(client.Users.FollowersIter(nil, &PageOptParams{Limit: 2}))
+	if err != nil {
+		t.Fatalf("users.followers_iter returned error: %v", err)
+	}
+
+	want := []UserResult{{ID: "test_id_1"}, {ID: "test_id_2"}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("users.followers_iter returned %+v, want %+v", users, want)
+	}
+}
+
+func TestFriendshipsService_RequestsIterError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/friendships/requests.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("page") == "2" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": "test error"}`)
+			return
+		}
+
+		fmt.Fprint(w, `[{"id": "test_id_1"}, {"id": "test_id_2"}]`)
+	})
+
+	users, err := Collect(client.Friendships.RequestsIter(&FriendshipsOptParams{Count: 2}, nil))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("friendships.requests_iter returned error %v, want ErrNotFound", err)
+	}
+
+	if len(users) != 2 {
+		t.Errorf("friendships.requests_iter returned %+v before the error, want 2 users", users)
+	}
+}
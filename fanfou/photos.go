@@ -1,6 +1,7 @@
 package fanfou
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
@@ -19,6 +20,13 @@ type PhotosService struct {
 	client *Client
 }
 
+// Photo specifies Fanfou's photos data structure, attached to a status
+type Photo struct {
+	Imageurl string `json:"imageurl,omitempty"`
+	Thumburl string `json:"thumburl,omitempty"`
+	Largeurl string `json:"largeurl,omitempty"`
+}
+
 // PhotosOptParams specifies the optional params for search API
 type PhotosOptParams struct {
 	ID       string
@@ -40,6 +48,12 @@ type PhotosOptParams struct {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/photos.user-timeline
 func (s *PhotosService) UserTimeline(opt *PhotosOptParams) ([]StatusResult, *string, error) {
+	return s.UserTimelineContext(context.Background(), opt)
+}
+
+// UserTimelineContext is the same as UserTimeline, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *PhotosService) UserTimelineContext(ctx context.Context, opt *PhotosOptParams) ([]StatusResult, *string, error) {
 	u := fmt.Sprintf("photos/user_timeline.json")
 	params := url.Values{}
 
@@ -69,7 +83,7 @@ func (s *PhotosService) UserTimeline(opt *PhotosOptParams) ([]StatusResult, *str
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -77,7 +91,7 @@ func (s *PhotosService) UserTimeline(opt *PhotosOptParams) ([]StatusResult, *str
 	newStatuses := new([]StatusResult)
 	resp, err := s.client.Do(req, newStatuses)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newStatuses, resp.BodyStrPtr, nil
@@ -87,6 +101,12 @@ func (s *PhotosService) UserTimeline(opt *PhotosOptParams) ([]StatusResult, *str
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/photos.upload
 func (s *PhotosService) Upload(filePath string, opt *PhotosOptParams) (*StatusResult, *string, error) {
+	return s.UploadContext(context.Background(), filePath, opt)
+}
+
+// UploadContext is the same as Upload, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *PhotosService) UploadContext(ctx context.Context, filePath string, opt *PhotosOptParams) (*StatusResult, *string, error) {
 	u := fmt.Sprintf("photos/upload.json")
 	params := map[string]string{}
 
@@ -109,7 +129,7 @@ func (s *PhotosService) Upload(filePath string, opt *PhotosOptParams) (*StatusRe
 	}
 
 	if URL, err := url.Parse(filePath); err == nil && URL.Scheme != "" {
-		localPath, err := fetchFile(URL.String())
+		localPath, err := s.fetchFile(ctx, URL.String())
 		if err != nil {
 			return nil, nil, err
 		}
@@ -117,14 +137,14 @@ func (s *PhotosService) Upload(filePath string, opt *PhotosOptParams) (*StatusRe
 		defer func() {
 			err := os.Remove(localPath)
 			if err != nil {
-				fmt.Printf("failed to remove tmp file: %+v\n", err)
+				s.client.logWarn(ctx, "fanfou: failed to remove tmp file", "path", localPath, "error", err)
 			}
 		}()
 
 		filePath = localPath
 	}
 
-	req, err := s.client.NewUploadRequest(http.MethodPost, u, params, "photo", filePath)
+	req, err := s.client.NewUploadRequestContext(ctx, http.MethodPost, u, params, "photo", filePath)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -132,14 +152,20 @@ func (s *PhotosService) Upload(filePath string, opt *PhotosOptParams) (*StatusRe
 	newStatuses := new(StatusResult)
 	resp, err := s.client.Do(req, newStatuses)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newStatuses, resp.BodyStrPtr, nil
 }
 
-func fetchFile(URL string) (string, error) {
-	resp, err := http.Get(URL)
+// fetchFile downloads the file at URL to a temporary file
+func (s *PhotosService) fetchFile(ctx context.Context, URL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, URL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := s.client.unsignedHTTPClient().Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -147,7 +173,7 @@ func fetchFile(URL string) (string, error) {
 	defer func() {
 		err := resp.Body.Close()
 		if err != nil {
-			fmt.Printf("failed to close body: %+v\n", err)
+			s.client.logWarn(ctx, "fanfou: failed to close body", "url", URL, "error", err)
 		}
 	}()
 
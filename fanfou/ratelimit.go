@@ -0,0 +1,267 @@
+package fanfou
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimitMarkers are the fragments of the error messages Fanfou returns
+// when a request is throttled
+var rateLimitMarkers = []string{
+	"rate limit",
+	"频率",
+	"频繁",
+}
+
+// RateLimitError is returned when the hourly rate limit of the access token
+// is exhausted, either as tracked by the RateLimiter of the client or as
+// reported by the API
+type RateLimitError struct {
+	// Limit is the hourly limit of the access token, if known
+	Limit int64
+
+	// Reset is the time the budget is reset, or the zero time if unknown
+	Reset time.Time
+
+	// Response is the error returned by the API, or nil if the request
+	// was stopped by the RateLimiter before being sent
+	Response *ErrorResponse
+}
+
+// Error implements the error interface
+func (e *RateLimitError) Error() string {
+	msg := "rate limit exceeded"
+	if !e.Reset.IsZero() {
+		msg += fmt.Sprintf(", reset at %s", e.Reset.Format(time.RFC3339))
+	}
+
+	if e.Response != nil {
+		return e.Response.Error() + " (" + msg + ")"
+	}
+
+	return msg
+}
+
+// Is makes RateLimitError match ErrRateLimited with errors.Is
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// Unwrap returns the error returned by the API, if any
+func (e *RateLimitError) Unwrap() error {
+	if e.Response == nil {
+		return nil
+	}
+
+	return e.Response
+}
+
+// isRateLimited reports whether the API error stands for a throttled request
+func isRateLimited(r *ErrorResponse) bool {
+	if r.Response == nil || r.Meta == nil {
+		return false
+	}
+
+	if r.Response.StatusCode != http.StatusBadRequest && r.Response.StatusCode != http.StatusForbidden {
+		return false
+	}
+
+	return containsAny(strings.ToLower(r.Meta.Error), rateLimitMarkers)
+}
+
+// A RateLimiter tracks the hourly budget of API calls of every access token
+// and stops requests once it is exhausted, instead of letting Fanfou reject
+// them. The budget is synced with account/rate_limit_status periodically.
+//
+// A RateLimiter is safe for concurrent use, and can be shared by several
+// clients. Its exported fields shall not be modified once it is in use.
+type RateLimiter struct {
+	// SyncInterval is how often the budget is synced with the API, or
+	// DefaultRateLimitSyncInterval if not positive
+	SyncInterval time.Duration
+
+	// Wait makes requests block until the budget is reset when it is
+	// exhausted, instead of failing with a RateLimitError right away
+	Wait bool
+
+	mu      sync.Mutex
+	budgets map[string]*rateBudget
+
+	// now is a seam for tests
+	now func() time.Time
+}
+
+// rateBudget is the budget of a single access token
+type rateBudget struct {
+	limit     int64
+	remaining int64
+	reset     time.Time
+	syncedAt  time.Time
+	syncing   bool
+}
+
+// DefaultRateLimitSyncInterval is how often a RateLimiter without a
+// SyncInterval syncs the budget with the API
+const DefaultRateLimitSyncInterval = 5 * time.Minute
+
+// rateLimitSyncKey marks the context of the requests sent by the
+// RateLimiter itself, which shall not be limited
+type rateLimitSyncKey struct{}
+
+// NewRateLimiter returns a RateLimiter syncing with the API every 5 minutes
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		SyncInterval: DefaultRateLimitSyncInterval,
+		budgets:      make(map[string]*rateBudget),
+	}
+}
+
+// clock returns the current time
+func (l *RateLimiter) clock() time.Time {
+	if l.now == nil {
+		return time.Now()
+	}
+
+	return l.now()
+}
+
+// syncInterval returns how often the budget is synced
+func (l *RateLimiter) syncInterval() time.Duration {
+	if l.SyncInterval <= 0 {
+		return DefaultRateLimitSyncInterval
+	}
+
+	return l.SyncInterval
+}
+
+// budget returns the budget of the token, creating it if needed.
+// l.mu shall be held.
+func (l *RateLimiter) budget(token string) *rateBudget {
+	if l.budgets == nil {
+		l.budgets = make(map[string]*rateBudget)
+	}
+
+	b, ok := l.budgets[token]
+	if !ok {
+		b = &rateBudget{}
+		l.budgets[token] = b
+	}
+
+	return b
+}
+
+// acquire takes one call from the budget of the client's token, syncing it
+// beforehand if it is stale
+func (l *RateLimiter) acquire(ctx context.Context, c *Client) error {
+	if ctx.Value(rateLimitSyncKey{}) != nil {
+		return nil
+	}
+
+	token := c.tokenKey()
+
+	for {
+		l.mu.Lock()
+		b := l.budget(token)
+		now := l.clock()
+
+		if !b.syncing && now.Sub(b.syncedAt) >= l.syncInterval() {
+			b.syncing = true
+			l.mu.Unlock()
+
+			l.sync(ctx, c, token)
+			continue
+		}
+
+		if !b.reset.IsZero() && !now.Before(b.reset) {
+			// The budget has been reset in the meantime, sync it again
+			b.reset = time.Time{}
+			b.syncedAt = time.Time{}
+			l.mu.Unlock()
+			continue
+		}
+
+		// Nothing is enforced until the budget is known
+		if b.reset.IsZero() || b.remaining > 0 {
+			b.remaining--
+			l.mu.Unlock()
+			return nil
+		}
+
+		limit, reset := b.limit, b.reset
+		l.mu.Unlock()
+
+		if !l.Wait {
+			return &RateLimitError{Limit: limit, Reset: reset}
+		}
+
+		if err := sleep(ctx, reset.Sub(now)); err != nil {
+			return err
+		}
+	}
+}
+
+// sync updates the budget of the token with account/rate_limit_status.
+// The current budget is kept if the API cannot be reached.
+func (l *RateLimiter) sync(ctx context.Context, c *Client, token string) {
+	status, _, err := c.Account.RateLimitStatusContext(context.WithValue(ctx, rateLimitSyncKey{}, true))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.budget(token)
+	b.syncing = false
+	b.syncedAt = l.clock()
+
+	if err != nil {
+		return
+	}
+
+	b.limit = status.HourlyLimit
+	b.remaining = status.RemainingHits
+	b.reset = time.Time{}
+
+	// A reset time already past is of no use to enforce anything
+	if reset := time.Unix(status.ResetTimeInSeconds, 0); reset.After(b.syncedAt) {
+		b.reset = reset
+	}
+}
+
+// remaining returns the remaining budget of the client's token, and whether
+// it is known
+func (l *RateLimiter) remaining(c *Client) (int64, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.budgets[c.tokenKey()]
+	if !ok || b.reset.IsZero() {
+		return 0, false
+	}
+
+	if b.remaining < 0 {
+		return 0, true
+	}
+
+	return b.remaining, true
+}
+
+// exhaust empties the budget of the client's token after the API throttled
+// a request, and returns the resulting RateLimitError
+func (l *RateLimiter) exhaust(c *Client, r *ErrorResponse) *RateLimitError {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.budget(c.tokenKey())
+	b.remaining = 0
+
+	now := l.clock()
+	if b.reset.Before(now) {
+		// Budgets are reset hourly
+		b.reset = now.Truncate(time.Hour).Add(time.Hour)
+	}
+
+	return &RateLimitError{Limit: b.limit, Reset: b.reset, Response: r}
+}
@@ -0,0 +1,185 @@
+package fanfou
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_failsFastWhenExhausted(t *testing.T) {
+	setup()
+	defer teardown()
+
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	mux.HandleFunc("/account/rate_limit_status.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, err := fmt.Fprintf(w, `{"remaining_hits": 2, "hourly_limit": 150, "reset_time_in_seconds": %d}`, reset.Unix())
+		if err != nil {
+			t.Errorf("account.rate_limit_status mock server error: %+v", err)
+		}
+	})
+
+	calls := 0
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, err := fmt.Fprint(w, `{"id": "test_id"}`)
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	client.RateLimiter = NewRateLimiter()
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Statuses.Show("test_id", nil); err != nil {
+			t.Fatalf("statuses.show returned error: %v", err)
+		}
+	}
+
+	_, _, err := client.Statuses.Show("test_id", nil)
+	rateLimitErr, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("statuses.show returned %v, want RateLimitError", err)
+	}
+
+	if !rateLimitErr.Reset.Equal(reset) {
+		t.Errorf("RateLimitError.Reset = %v, want %v", rateLimitErr.Reset, reset)
+	}
+
+	if rateLimitErr.Limit != 150 {
+		t.Errorf("RateLimitError.Limit = %v, want %v", rateLimitErr.Limit, 150)
+	}
+
+	if calls != 2 {
+		t.Errorf("statuses.show was sent %d times, want %d", calls, 2)
+	}
+}
+
+func TestRateLimiter_waitsForReset(t *testing.T) {
+	setup()
+	defer teardown()
+
+	syncs := 0
+	mux.HandleFunc("/account/rate_limit_status.json", func(w http.ResponseWriter, r *http.Request) {
+		syncs++
+
+		// The budget is exhausted until the first reset, 1 second later
+		remaining := 0
+		reset := time.Now().Add(time.Second)
+		if syncs > 1 {
+			remaining = 150
+			reset = time.Now().Add(time.Hour)
+		}
+
+		_, err := fmt.Fprintf(w, `{"remaining_hits": %d, "hourly_limit": 150, "reset_time_in_seconds": %d}`, remaining, reset.Unix())
+		if err != nil {
+			t.Errorf("account.rate_limit_status mock server error: %+v", err)
+		}
+	})
+
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprint(w, `{"id": "test_id"}`)
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	client.RateLimiter = NewRateLimiter()
+	client.RateLimiter.Wait = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, _, err := client.Statuses.ShowContext(ctx, "test_id", nil); err != nil {
+		t.Fatalf("statuses.show returned error: %v", err)
+	}
+
+	if syncs != 2 {
+		t.Errorf("account.rate_limit_status was sent %d times, want %d", syncs, 2)
+	}
+}
+
+func TestRateLimiter_zeroValue(t *testing.T) {
+	setup()
+	defer teardown()
+
+	syncs := 0
+	mux.HandleFunc("/account/rate_limit_status.json", func(w http.ResponseWriter, r *http.Request) {
+		syncs++
+		_, err := fmt.Fprintf(w, `{"remaining_hits": 150, "hourly_limit": 150, "reset_time_in_seconds": %d}`, time.Now().Add(time.Hour).Unix())
+		if err != nil {
+			t.Errorf("account.rate_limit_status mock server error: %+v", err)
+		}
+	})
+
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprint(w, `{"id": "test_id"}`)
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	client.RateLimiter = &RateLimiter{}
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.Statuses.Show("test_id", nil); err != nil {
+			t.Fatalf("statuses.show returned error: %v", err)
+		}
+	}
+
+	if syncs != 1 {
+		t.Errorf("account.rate_limit_status was sent %d times, want %d", syncs, 1)
+	}
+}
+
+func TestRateLimiter_throttledByAPI(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusForbidden)
+		_, err := fmt.Fprint(w, `{"request": "/statuses/show.json", "error": "Rate limit exceeded"}`)
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	// Without a RateLimiter, the error is typed but the reset time is unknown
+	_, _, err := client.Statuses.Show("test_id", nil)
+	rateLimitErr, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("statuses.show returned %v, want RateLimitError", err)
+	}
+
+	if rateLimitErr.Response == nil || rateLimitErr.Response.GetFanfouError() != "Rate limit exceeded" {
+		t.Errorf("RateLimitError.Response = %+v, want the API error", rateLimitErr.Response)
+	}
+
+	// With a RateLimiter which could not sync, the budget is exhausted until
+	// the next hour and further requests are not sent anymore
+	client.RateLimiter = NewRateLimiter()
+
+	_, _, err = client.Statuses.Show("test_id", nil)
+	rateLimitErr, ok = err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("statuses.show returned %v, want RateLimitError", err)
+	}
+
+	if rateLimitErr.Reset.IsZero() {
+		t.Errorf("RateLimitError.Reset is zero, want the next hour")
+	}
+
+	_, _, err = client.Statuses.Show("test_id", nil)
+	if _, ok := err.(*RateLimitError); !ok {
+		t.Fatalf("statuses.show returned %v, want RateLimitError", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("statuses.show was sent %d times, want %d", calls, 2)
+	}
+}
@@ -0,0 +1,201 @@
+package fanfou
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultRetryableStatus lists the status codes retried when a RetryPolicy
+// does not specify its own
+var DefaultRetryableStatus = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// idempotentEndpoints lists the POST endpoints which can be sent twice
+// without side effects, e.g. following a user who is already followed.
+//
+// Endpoints creating new content such as statuses/update, photos/upload or
+// direct_messages/new are deliberately absent: they are never retried.
+var idempotentEndpoints = map[string]bool{
+	"statuses/destroy":              true,
+	"favorites/create":              true,
+	"favorites/destroy":             true,
+	"friendships/create":            true,
+	"friendships/destroy":           true,
+	"friendships/accept":            true,
+	"friendships/deny":              true,
+	"blocks/create":                 true,
+	"blocks/destroy":                true,
+	"direct_messages/destroy":       true,
+	"saved_searches/destroy":        true,
+	"account/update_profile":        true,
+	"account/update_notify_num":     true,
+	"2/users/cancel_recommendation": true,
+}
+
+// RetryPolicy specifies how a Client retries requests which failed with a
+// transient error, i.e. a network error or a retryable status code.
+//
+// Only idempotent requests are retried: GET requests, and POST requests to
+// the endpoints which are safe to send twice. A status is never posted twice.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+
+	// MinBackoff is the wait before the first retry, doubled for every
+	// following retry
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the wait between two attempts
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction, between 0 and 1, of every wait which is
+	// randomized so that concurrent clients don't retry in lockstep
+	Jitter float64
+
+	// RetryableStatus lists the status codes worth a retry.
+	// DefaultRetryableStatus is used if nil.
+	RetryableStatus []int
+
+	// OnRetry is called, if not nil, every time a request is about to be
+	// retried
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a failed attempt which is about to be retried
+type RetryEvent struct {
+	// Attempt is the number of the failed attempt, starting at 1
+	Attempt int
+
+	// Request is the request of the failed attempt
+	Request *http.Request
+
+	// Response is the response of the failed attempt, or nil if the
+	// attempt failed with a network error
+	Response *http.Response
+
+	// Err is the error of the failed attempt
+	Err error
+
+	// Wait is the delay before the next attempt
+	Wait time.Duration
+}
+
+// DefaultRetryPolicy returns a RetryPolicy making up to 3 attempts, waiting
+// about 500ms then 1s between them
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// shouldRetry reports whether the failed attempt is worth a retry
+func (p *RetryPolicy) shouldRetry(attempt int, endpoint string, req *http.Request, resp *http.Response, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+
+	if req.Method == http.MethodPost && !idempotentEndpoints[endpoint] {
+		return false
+	}
+
+	// Network errors are always wrapped in a *url.Error by http.Client,
+	// which the middleware may wrap again, unlike errors of the middleware
+	// hooks themselves which are not retried
+	if resp == nil {
+		var urlErr *url.Error
+		return errors.As(err, &urlErr)
+	}
+
+	retryableStatus := p.RetryableStatus
+	if retryableStatus == nil {
+		retryableStatus = DefaultRetryableStatus
+	}
+
+	for _, code := range retryableStatus {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the wait after the given failed attempt, honoring the
+// Retry-After header of the response if any
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			wait := time.Duration(seconds) * time.Second
+			if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+				wait = p.MaxBackoff
+			}
+			return wait
+		}
+	}
+
+	wait := p.MinBackoff
+	for i := 1; i < attempt; i++ {
+		wait *= 2
+		if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+			break
+		}
+	}
+
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		jitter := time.Duration(p.Jitter * float64(wait))
+		wait = wait - jitter + time.Duration(rand.Int63n(int64(jitter)+1))
+	}
+
+	return wait
+}
+
+// sleep waits for d, or returns the context's error if ctx is done earlier
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rewindRequest returns a copy of req with a fresh body so it can be sent
+// once more
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	newReq := req.Clone(req.Context())
+	if req.GetBody == nil {
+		return newReq, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	newReq.Body = body
+
+	return newReq, nil
+}
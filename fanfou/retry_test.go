@@ -0,0 +1,229 @@
+package fanfou
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_retriesIdempotentRequests(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := fmt.Fprint(w, `{"id": "test_id"}`)
+		if err != nil {
+			t.Errorf("statuses.show mock server error: %+v", err)
+		}
+	})
+
+	var events []RetryEvent
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		OnRetry: func(e RetryEvent) {
+			events = append(events, e)
+		},
+	}
+
+	status, _, err := client.Statuses.Show("test_id", nil)
+	if err != nil {
+		t.Fatalf("statuses.show returned error: %v", err)
+	}
+
+	if status.ID != "test_id" {
+		t.Errorf("statuses.show returned ID %v, want %v", status.ID, "test_id")
+	}
+
+	if calls != 3 {
+		t.Errorf("statuses.show was sent %d times, want %d", calls, 3)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("OnRetry was called %d times, want %d", len(events), 2)
+	}
+
+	for i, e := range events {
+		if e.Attempt != i+1 {
+			t.Errorf("OnRetry event %d has attempt %d, want %d", i, e.Attempt, i+1)
+		}
+		if e.Response == nil || e.Response.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("OnRetry event %d has response %+v, want status %d", i, e.Response, http.StatusServiceUnavailable)
+		}
+	}
+}
+
+func TestRetryPolicy_givesUpAfterMaxAttempts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+	}
+
+	_, _, err := client.Statuses.Show("test_id", nil)
+	fanfouErr, ok := err.(*ErrorResponse)
+	if !ok {
+		t.Fatalf("statuses.show returned %v, want ErrorResponse", err)
+	}
+
+	if fanfouErr.GetStatusCode() != "502" {
+		t.Errorf("statuses.show returned status %v, want %v", fanfouErr.GetStatusCode(), "502")
+	}
+
+	if calls != 2 {
+		t.Errorf("statuses.show was sent %d times, want %d", calls, 2)
+	}
+}
+
+func TestRetryPolicy_neverRetriesStatusUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 5,
+		MinBackoff:  time.Millisecond,
+	}
+
+	_, _, err := client.Statuses.Update("status text", nil)
+	if err == nil {
+		t.Errorf("statuses.update returned %v, want err", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("statuses.update was sent %d times, want %d", calls, 1)
+	}
+}
+
+func TestRetryPolicy_retriesIdempotentPOST(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/favorites/create.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"id": "test_id"})
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, err := fmt.Fprint(w, `{"id": "test_id"}`)
+		if err != nil {
+			t.Errorf("favorites.create mock server error: %+v", err)
+		}
+	})
+
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+	}
+
+	_, _, err := client.Favorites.Create("test_id", nil)
+	if err != nil {
+		t.Errorf("favorites.create returned error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("favorites.create was sent %d times, want %d", calls, 2)
+	}
+}
+
+func TestRetryPolicy_contextCanceledWhileWaiting(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Hour,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, err := client.Statuses.ShowContext(ctx, "test_id", nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("statuses.show returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRetryPolicy_shouldRetryWrappedNetworkError(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 3}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/statuses/show.json", nil)
+
+	netErr := &url.Error{Op: "Get", URL: req.URL.String(), Err: errors.New("connection reset")}
+
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{netErr, true},
+		{fmt.Errorf("middleware: %w", netErr), true},
+		{errors.New("middleware error"), false},
+	}
+
+	for _, tt := range tests {
+		if got := p.shouldRetry(1, "statuses/show", req, nil, tt.err); got != tt.want {
+			t.Errorf("shouldRetry(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{
+		MinBackoff: 100 * time.Millisecond,
+		MaxBackoff: 300 * time.Millisecond,
+	}
+
+	for attempt, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 300 * time.Millisecond,
+		9: 300 * time.Millisecond,
+	} {
+		if got := p.backoff(attempt, nil); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"0"}}}
+	if got := p.backoff(1, resp); got != 0 {
+		t.Errorf("backoff() with Retry-After = %v, want %v", got, 0)
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(1, nil); got < 50*time.Millisecond || got > 100*time.Millisecond {
+			t.Fatalf("backoff() with jitter = %v, want between %v and %v", got, 50*time.Millisecond, 100*time.Millisecond)
+		}
+	}
+}
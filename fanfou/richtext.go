@@ -0,0 +1,326 @@
+package fanfou
+
+import (
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// webBaseURL is the base URL of the Fanfou website, which the links of the
+// rendered mentions and tags point to
+const webBaseURL = "https://fanfou.com/"
+
+// SegmentType is the type of a Segment of a status text
+type SegmentType int
+
+const (
+	// SegmentText is plain text
+	SegmentText SegmentType = iota
+
+	// SegmentMention is a mention of a user, e.g. "@name"
+	SegmentMention
+
+	// SegmentTag is a tag, e.g. "#tag#"
+	SegmentTag
+
+	// SegmentURL is a link
+	SegmentURL
+)
+
+// String returns the name of the segment type
+func (t SegmentType) String() string {
+	switch t {
+	case SegmentText:
+		return "text"
+	case SegmentMention:
+		return "mention"
+	case SegmentTag:
+		return "tag"
+	case SegmentURL:
+		return "url"
+	}
+
+	return "unknown"
+}
+
+// A Segment is a piece of a status text
+type Segment struct {
+	// Type is the type of the segment
+	Type SegmentType
+
+	// Text is the text of the segment, without HTML: the plain text, the
+	// name of the mentioned user without "@", the tag without "#" or the
+	// text of the link
+	Text string
+
+	// UserID is the ID of the mentioned user, for mentions only
+	UserID string
+
+	// URL is the target of the link, for links only
+	URL string
+}
+
+// RichText is a status text parsed into segments, see ParseRichText
+type RichText []Segment
+
+var (
+	// anchorPattern matches the links of a status text
+	anchorPattern = regexp.MustCompile(`(?is)<a\s([^>]*)>(.*?)</a>`)
+
+	// hrefPattern matches the target of a link
+	hrefPattern = regexp.MustCompile(`(?is)\bhref\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+	// tagPattern matches any other HTML tag, which is stripped
+	tagPattern = regexp.MustCompile(`(?s)<[^>]*>`)
+
+	// markdownSpecials matches the characters escaped in Markdown text
+	markdownSpecials = regexp.MustCompile("[\\\\`*_{}\\[\\]()#+\\-.!<>|~]")
+)
+
+// ParseRichText parses the text of a status, as returned by Fanfou with its
+// links and HTML entities, into segments. Mentions are links to a user
+// preceded by "@", and tags links to a search surrounded by "#".
+func ParseRichText(text string) RichText {
+	var rt RichText
+
+	last := 0
+	for _, match := range anchorPattern.FindAllStringSubmatchIndex(text, -1) {
+		rt = rt.appendText(plainText(text[last:match[0]]))
+		last = match[1]
+
+		href := html.UnescapeString(anchorHref(text[match[2]:match[3]]))
+		inner := plainText(text[match[4]:match[5]])
+		rest := text[match[1]:]
+
+		switch {
+		case isUserURL(href) && rt.trimTextSuffix("@"):
+			rt = append(rt, Segment{Type: SegmentMention, Text: inner, UserID: userIDOf(href)})
+
+		case strings.HasPrefix(rest, "#") && isSearchURL(href) && rt.trimTextSuffix("#"):
+			rt = append(rt, Segment{Type: SegmentTag, Text: inner})
+			last++
+
+		default:
+			rt = append(rt, Segment{Type: SegmentURL, Text: inner, URL: href})
+		}
+	}
+
+	return rt.appendText(plainText(text[last:]))
+}
+
+// RichText parses the text of the status, see ParseRichText
+func (s *StatusResult) RichText() RichText {
+	return ParseRichText(s.Text)
+}
+
+// Mentions returns the mentions of the text
+func (rt RichText) Mentions() []Segment {
+	return rt.filter(SegmentMention)
+}
+
+// Tags returns the tags of the text
+func (rt RichText) Tags() []Segment {
+	return rt.filter(SegmentTag)
+}
+
+// URLs returns the links of the text
+func (rt RichText) URLs() []Segment {
+	return rt.filter(SegmentURL)
+}
+
+// PlainText renders the text as plain text, as it was typed by its author
+func (rt RichText) PlainText() string {
+	var b strings.Builder
+
+	for _, seg := range rt {
+		switch seg.Type {
+		case SegmentMention:
+			b.WriteString("@" + seg.Text)
+		case SegmentTag:
+			b.WriteString("#" + seg.Text + "#")
+		case SegmentURL:
+			if seg.URL == "" {
+				b.WriteString(seg.Text)
+				continue
+			}
+			b.WriteString(seg.URL)
+		default:
+			b.WriteString(seg.Text)
+		}
+	}
+
+	return b.String()
+}
+
+// Markdown renders the text as Markdown, mentions and tags linking to the
+// Fanfou website
+func (rt RichText) Markdown() string {
+	var b strings.Builder
+
+	for _, seg := range rt {
+		switch seg.Type {
+		case SegmentMention:
+			b.WriteString("[@" + escapeMarkdown(seg.Text) + "](" + markdownURL(userURL(seg.UserID)) + ")")
+		case SegmentTag:
+			b.WriteString("[#" + escapeMarkdown(seg.Text) + "#](" + markdownURL(tagURL(seg.Text)) + ")")
+		case SegmentURL:
+			if !isWebURL(seg.URL) {
+				b.WriteString(escapeMarkdown(seg.Text))
+			} else if seg.Text == seg.URL {
+				b.WriteString("<" + markdownURL(seg.URL) + ">")
+			} else {
+				b.WriteString("[" + escapeMarkdown(seg.Text) + "](" + markdownURL(seg.URL) + ")")
+			}
+		default:
+			b.WriteString(escapeMarkdown(seg.Text))
+		}
+	}
+
+	return b.String()
+}
+
+// HTML renders the text as HTML which is safe to embed in a page: the text
+// is escaped, and only links to http and https URLs are kept
+func (rt RichText) HTML() string {
+	var b strings.Builder
+
+	for _, seg := range rt {
+		switch seg.Type {
+		case SegmentMention:
+			b.WriteString(`@<a href="` + html.EscapeString(userURL(seg.UserID)) + `">` + html.EscapeString(seg.Text) + `</a>`)
+		case SegmentTag:
+			b.WriteString(`#<a href="` + html.EscapeString(tagURL(seg.Text)) + `">` + html.EscapeString(seg.Text) + `</a>#`)
+		case SegmentURL:
+			if !isWebURL(seg.URL) {
+				b.WriteString(html.EscapeString(seg.Text))
+				continue
+			}
+			b.WriteString(`<a href="` + html.EscapeString(seg.URL) + `" rel="nofollow noopener" target="_blank">` + html.EscapeString(seg.Text) + `</a>`)
+		default:
+			b.WriteString(html.EscapeString(seg.Text))
+		}
+	}
+
+	return b.String()
+}
+
+// appendText appends plain text to rt, merging it with the last segment if
+// it is plain text too
+func (rt RichText) appendText(text string) RichText {
+	if text == "" {
+		return rt
+	}
+
+	if n := len(rt); n > 0 && rt[n-1].Type == SegmentText {
+		rt[n-1].Text += text
+		return rt
+	}
+
+	return append(rt, Segment{Type: SegmentText, Text: text})
+}
+
+// trimTextSuffix removes suffix from the last segment if it is plain text
+// ending with it, and reports whether it did
+func (rt *RichText) trimTextSuffix(suffix string) bool {
+	n := len(*rt)
+	if n == 0 || (*rt)[n-1].Type != SegmentText || !strings.HasSuffix((*rt)[n-1].Text, suffix) {
+		return false
+	}
+
+	(*rt)[n-1].Text = strings.TrimSuffix((*rt)[n-1].Text, suffix)
+	if (*rt)[n-1].Text == "" {
+		*rt = (*rt)[:n-1]
+	}
+
+	return true
+}
+
+// filter returns the segments of the given type
+func (rt RichText) filter(t SegmentType) []Segment {
+	var segs []Segment
+	for _, seg := range rt {
+		if seg.Type == t {
+			segs = append(segs, seg)
+		}
+	}
+
+	return segs
+}
+
+// plainText strips the HTML tags of s and unescapes its entities
+func plainText(s string) string {
+	return html.UnescapeString(tagPattern.ReplaceAllString(s, ""))
+}
+
+// anchorHref returns the raw href attribute of a link
+func anchorHref(attrs string) string {
+	match := hrefPattern.FindStringSubmatch(attrs)
+	if match == nil {
+		return ""
+	}
+
+	if match[1] != "" {
+		return match[1]
+	}
+
+	return match[2]
+}
+
+// isFanfouURL reports whether u points to the Fanfou website
+func isFanfouURL(u *url.URL) bool {
+	host := strings.ToLower(u.Host)
+	return host == "" || host == "fanfou.com" || host == "www.fanfou.com" || host == "m.fanfou.com"
+}
+
+// isUserURL reports whether href is the page of a user
+func isUserURL(href string) bool {
+	u, err := url.Parse(href)
+	if err != nil || !isFanfouURL(u) {
+		return false
+	}
+
+	p := strings.Trim(u.Path, "/")
+	return p != "" && !strings.Contains(p, "/")
+}
+
+// isSearchURL reports whether href is a search on the Fanfou website
+func isSearchURL(href string) bool {
+	u, err := url.Parse(href)
+	return err == nil && isFanfouURL(u) && strings.HasPrefix(u.Path, "/q/")
+}
+
+// isWebURL reports whether href is a http or https URL, which is safe to
+// link to
+func isWebURL(href string) bool {
+	u, err := url.Parse(href)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// userIDOf returns the ID of the user whose page is href
+func userIDOf(href string) string {
+	u, _ := url.Parse(href)
+	return path.Base(u.Path)
+}
+
+// userURL returns the page of the user on the Fanfou website
+func userURL(userID string) string {
+	return webBaseURL + url.PathEscape(userID)
+}
+
+// tagURL returns the search of the tag on the Fanfou website
+func tagURL(tag string) string {
+	return webBaseURL + "q/" + url.PathEscape(tag)
+}
+
+// escapeMarkdown escapes the characters of s which Markdown would interpret
+func escapeMarkdown(s string) string {
+	return markdownSpecials.ReplaceAllString(s, `\$0`)
+}
+
+// markdownURL escapes the characters of the URL which would end a Markdown
+// link early
+func markdownURL(u string) string {
+	return strings.NewReplacer("(", "%28", ")", "%29", " ", "%20", "<", "%3C", ">", "%3E").Replace(u)
+}
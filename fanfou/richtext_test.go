@@ -0,0 +1,79 @@
+package fanfou
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testStatusHTML = `转@<a href="http://fanfou.com/test_user_id" class="former">测试 用户</a> 看看 #<a href="/q/%E9%A5%AD%E5%90%A6">饭否</a># 的 <a href="http://example.com/a?b=1&amp;c=2" title="http://example.com/a?b=1&amp;c=2" rel="nofollow" target="_blank">http://example.com/a?b=1&amp;c=2</a> &lt;3 &amp; *emphasis*`
+
+func TestParseRichText(t *testing.T) {
+	got := ParseRichText(testStatusHTML)
+
+	want := RichText{
+		{Type: SegmentText, Text: "转"},
+		{Type: SegmentMention, Text: "测试 用户", UserID: "test_user_id"},
+		{Type: SegmentText, Text: " 看看 "},
+		{Type: SegmentTag, Text: "饭否"},
+		{Type: SegmentText, Text: " 的 "},
+		{Type: SegmentURL, Text: "http://example.com/a?b=1&c=2", URL: "http://example.com/a?b=1&c=2"},
+		{Type: SegmentText, Text: " <3 & *emphasis*"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseRichText returned %+v, want %+v", got, want)
+	}
+
+	if mentions := got.Mentions(); len(mentions) != 1 || mentions[0].UserID != "test_user_id" {
+		t.Errorf("RichText.Mentions() returned %+v, want the mention of test_user_id", mentions)
+	}
+
+	if tags := got.Tags(); len(tags) != 1 || tags[0].Text != "饭否" {
+		t.Errorf("RichText.Tags() returned %+v, want the tag 饭否", tags)
+	}
+
+	if urls := got.URLs(); len(urls) != 1 {
+		t.Errorf("RichText.URLs() returned %+v, want 1 link", urls)
+	}
+}
+
+func TestParseRichText_plain(t *testing.T) {
+	got := ParseRichText("no links &amp; an email@example.com #not a tag")
+
+	want := RichText{{Type: SegmentText, Text: "no links & an email@example.com #not a tag"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseRichText returned %+v, want %+v", got, want)
+	}
+
+	if got := ParseRichText(""); len(got) != 0 {
+		t.Errorf("ParseRichText of an empty text returned %+v, want no segment", got)
+	}
+}
+
+func TestRichText_render(t *testing.T) {
+	rt := ParseRichText(testStatusHTML)
+
+	wantPlain := "转@测试 用户 看看 #饭否# 的 http://example.com/a?b=1&c=2 <3 & *emphasis*"
+	if got := rt.PlainText(); got != wantPlain {
+		t.Errorf("RichText.PlainText() = %v, want %v", got, wantPlain)
+	}
+
+	wantMarkdown := `转[@测试 用户](https://fanfou.com/test_user_id) 看看 [#饭否#](https://fanfou.com/q/%E9%A5%AD%E5%90%A6) 的 <http://example.com/a?b=1&c=2> \<3 & \*emphasis\*`
+	if got := rt.Markdown(); got != wantMarkdown {
+		t.Errorf("RichText.Markdown() = %v, want %v", got, wantMarkdown)
+	}
+
+	wantHTML := `转@<a href="https://fanfou.com/test_user_id">测试 用户</a> 看看 #<a href="https://fanfou.com/q/%E9%A5%AD%E5%90%A6">饭否</a># 的 <a href="http://example.com/a?b=1&amp;c=2" rel="nofollow noopener" target="_blank">http://example.com/a?b=1&amp;c=2</a> &lt;3 &amp; *emphasis*`
+	if got := rt.HTML(); got != wantHTML {
+		t.Errorf("RichText.HTML() = %v, want %v", got, wantHTML)
+	}
+}
+
+func TestRichText_HTMLUnsafeLinks(t *testing.T) {
+	rt := ParseRichText(`<a href="javascript:alert(1)">click</a> <img src=x onerror=alert(1)>`)
+
+	want := "click "
+	if got := rt.HTML(); got != want {
+		t.Errorf("RichText.HTML() = %v, want %v", got, want)
+	}
+}
@@ -1,6 +1,7 @@
 package fanfou
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -16,16 +17,22 @@ type SavedSearchesService struct {
 
 // SavedSearchResult is the structure of saved search
 type SavedSearchResult struct {
-	ID        int64  `json:"id,omitempty"`
-	Query     string `json:"query,omitempty"`
-	Name      string `json:"name,omitempty"`
-	CreatedAt string `json:"created_at,omitempty"`
+	ID        int64      `json:"id,omitempty"`
+	Query     string     `json:"query,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	CreatedAt *Timestamp `json:"created_at,omitempty"`
 }
 
 // Show shall get a saved searches detail
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/saved-searches.show
 func (s *SavedSearchesService) Show(ID string) (*SavedSearchResult, *string, error) {
+	return s.ShowContext(context.Background(), ID)
+}
+
+// ShowContext is the same as Show, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *SavedSearchesService) ShowContext(ctx context.Context, ID string) (*SavedSearchResult, *string, error) {
 	u := fmt.Sprintf("saved_searches/show.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -33,7 +40,7 @@ func (s *SavedSearchesService) Show(ID string) (*SavedSearchResult, *string, err
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -41,7 +48,7 @@ func (s *SavedSearchesService) Show(ID string) (*SavedSearchResult, *string, err
 	newSavedSearch := new(SavedSearchResult)
 	resp, err := s.client.Do(req, newSavedSearch)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newSavedSearch, resp.BodyStrPtr, nil
@@ -51,9 +58,15 @@ func (s *SavedSearchesService) Show(ID string) (*SavedSearchResult, *string, err
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/saved-searches.list
 func (s *SavedSearchesService) List() ([]SavedSearchResult, *string, error) {
+	return s.ListContext(context.Background())
+}
+
+// ListContext is the same as List, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *SavedSearchesService) ListContext(ctx context.Context) ([]SavedSearchResult, *string, error) {
 	u := fmt.Sprintf("saved_searches/list.json")
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -61,7 +74,7 @@ func (s *SavedSearchesService) List() ([]SavedSearchResult, *string, error) {
 	newSavedSearches := new([]SavedSearchResult)
 	resp, err := s.client.Do(req, newSavedSearches)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newSavedSearches, resp.BodyStrPtr, nil
@@ -71,12 +84,18 @@ func (s *SavedSearchesService) List() ([]SavedSearchResult, *string, error) {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/saved-searches.create
 func (s *SavedSearchesService) Create(query string) (*SavedSearchResult, *string, error) {
+	return s.CreateContext(context.Background(), query)
+}
+
+// CreateContext is the same as Create, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *SavedSearchesService) CreateContext(ctx context.Context, query string) (*SavedSearchResult, *string, error) {
 	u := fmt.Sprintf("saved_searches/create.json")
 	params := url.Values{
 		"query": []string{query},
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -84,7 +103,7 @@ func (s *SavedSearchesService) Create(query string) (*SavedSearchResult, *string
 	newSavedSearch := new(SavedSearchResult)
 	resp, err := s.client.Do(req, newSavedSearch)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newSavedSearch, resp.BodyStrPtr, nil
@@ -94,12 +113,18 @@ func (s *SavedSearchesService) Create(query string) (*SavedSearchResult, *string
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/saved-searches.destroy
 func (s *SavedSearchesService) Destroy(ID string) (*SavedSearchResult, *string, error) {
+	return s.DestroyContext(context.Background(), ID)
+}
+
+// DestroyContext is the same as Destroy, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *SavedSearchesService) DestroyContext(ctx context.Context, ID string) (*SavedSearchResult, *string, error) {
 	u := fmt.Sprintf("saved_searches/destroy.json")
 	params := url.Values{
 		"id": []string{ID},
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -107,7 +132,7 @@ func (s *SavedSearchesService) Destroy(ID string) (*SavedSearchResult, *string,
 	newSavedSearch := new(SavedSearchResult)
 	resp, err := s.client.Do(req, newSavedSearch)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newSavedSearch, resp.BodyStrPtr, nil
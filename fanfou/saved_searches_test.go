@@ -28,7 +28,7 @@ func TestSavedSearchesService_Show(t *testing.T) {
 		ID:        21071,
 		Name:      "fanfou|test",
 		Query:     "fanfou|test",
-		CreatedAt: "Thu Nov 10 09:05:03 +0000 2011",
+		CreatedAt: mustParseTimestamp("Thu Nov 10 09:05:03 +0000 2011"),
 	}
 
 	if !reflect.DeepEqual(user, want) {
@@ -58,12 +58,12 @@ func TestSavedSearchesService_List(t *testing.T) {
 			ID:        21071,
 			Name:      "fanfou|test",
 			Query:     "fanfou|test",
-			CreatedAt: "Thu Nov 10 09:05:03 +0000 2011",
+			CreatedAt: mustParseTimestamp("Thu Nov 10 09:05:03 +0000 2011"),
 		}, {
 			ID:        21071,
 			Name:      "fanfou|test",
 			Query:     "fanfou|test",
-			CreatedAt: "Thu Nov 10 09:05:03 +0000 2011",
+			CreatedAt: mustParseTimestamp("Thu Nov 10 09:05:03 +0000 2011"),
 		},
 	}
 
@@ -93,7 +93,7 @@ func TestSavedSearchesService_Create(t *testing.T) {
 		ID:        21071,
 		Name:      "fanfou|test",
 		Query:     "fanfou|test",
-		CreatedAt: "Thu Nov 10 09:05:03 +0000 2011",
+		CreatedAt: mustParseTimestamp("Thu Nov 10 09:05:03 +0000 2011"),
 	}
 
 	if !reflect.DeepEqual(user, want) {
@@ -122,7 +122,7 @@ func TestSavedSearchesService_Destroy(t *testing.T) {
 		ID:        21071,
 		Name:      "fanfou|test",
 		Query:     "fanfou|test",
-		CreatedAt: "Thu Nov 10 09:05:03 +0000 2011",
+		CreatedAt: mustParseTimestamp("Thu Nov 10 09:05:03 +0000 2011"),
 	}
 
 	if !reflect.DeepEqual(user, want) {
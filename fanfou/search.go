@@ -1,6 +1,7 @@
 package fanfou
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -36,6 +37,12 @@ type SearchOptParams struct {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/search.public-timeline
 func (s *SearchService) PublicTimeline(q string, opt *SearchOptParams) ([]StatusResult, *string, error) {
+	return s.PublicTimelineContext(context.Background(), q, opt)
+}
+
+// PublicTimelineContext is the same as PublicTimeline, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *SearchService) PublicTimelineContext(ctx context.Context, q string, opt *SearchOptParams) ([]StatusResult, *string, error) {
 	u := fmt.Sprintf("search/public_timeline.json")
 	params := url.Values{
 		"q": []string{q},
@@ -61,7 +68,7 @@ func (s *SearchService) PublicTimeline(q string, opt *SearchOptParams) ([]Status
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -69,7 +76,7 @@ func (s *SearchService) PublicTimeline(q string, opt *SearchOptParams) ([]Status
 	newStatuses := new([]StatusResult)
 	resp, err := s.client.Do(req, newStatuses)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newStatuses, resp.BodyStrPtr, nil
@@ -82,6 +89,12 @@ func (s *SearchService) PublicTimeline(q string, opt *SearchOptParams) ([]Status
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/search.user-timeline
 func (s *SearchService) UserTimeline(q string, opt *SearchOptParams) ([]StatusResult, *string, error) {
+	return s.UserTimelineContext(context.Background(), q, opt)
+}
+
+// UserTimelineContext is the same as UserTimeline, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *SearchService) UserTimelineContext(ctx context.Context, q string, opt *SearchOptParams) ([]StatusResult, *string, error) {
 	u := fmt.Sprintf("search/user_timeline.json")
 	params := url.Values{
 		"q": []string{q},
@@ -110,7 +123,7 @@ func (s *SearchService) UserTimeline(q string, opt *SearchOptParams) ([]StatusRe
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -118,7 +131,7 @@ func (s *SearchService) UserTimeline(q string, opt *SearchOptParams) ([]StatusRe
 	newStatuses := new([]StatusResult)
 	resp, err := s.client.Do(req, newStatuses)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newStatuses, resp.BodyStrPtr, nil
@@ -128,6 +141,12 @@ func (s *SearchService) UserTimeline(q string, opt *SearchOptParams) ([]StatusRe
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/search.users
 func (s *SearchService) Users(q string, opt *SearchOptParams) (*SearchUsersResult, *string, error) {
+	return s.UsersContext(context.Background(), q, opt)
+}
+
+// UsersContext is the same as Users, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *SearchService) UsersContext(ctx context.Context, q string, opt *SearchOptParams) (*SearchUsersResult, *string, error) {
 	u := fmt.Sprintf("search/users.json")
 	params := url.Values{
 		"q": []string{q},
@@ -150,7 +169,7 @@ func (s *SearchService) Users(q string, opt *SearchOptParams) (*SearchUsersResul
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -158,7 +177,7 @@ func (s *SearchService) Users(q string, opt *SearchOptParams) (*SearchUsersResul
 	newSearchUsersResult := new(SearchUsersResult)
 	resp, err := s.client.Do(req, newSearchUsersResult)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newSearchUsersResult, resp.BodyStrPtr, nil
@@ -1,10 +1,12 @@
 package fanfou
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // StatusesService handles communication with the statuses related
@@ -17,7 +19,7 @@ type StatusesService struct {
 
 // StatusResult specifies Fanfou's statuses data structure
 type StatusResult struct {
-	CreatedAt           string        `json:"created_at,omitempty"`
+	CreatedAt           *Timestamp    `json:"created_at,omitempty"`
 	ID                  string        `json:"id,omitempty"`
 	Rawid               int64         `json:"rawid,omitempty"`
 	Text                string        `json:"text,omitempty"`
@@ -32,39 +34,49 @@ type StatusResult struct {
 	RepostUserID        string        `json:"repost_user_id,omitempty"`
 	RepostScreenName    string        `json:"repost_screen_name,omitempty"`
 	Favorited           bool          `json:"favorited,omitempty"`
-	User                struct {
-		ID                        string `json:"id,omitempty"`
-		Name                      string `json:"name,omitempty"`
-		ScreenName                string `json:"screen_name,omitempty"`
-		Location                  string `json:"location,omitempty"`
-		Gender                    string `json:"gender,omitempty"`
-		Birthday                  string `json:"birthday,omitempty"`
-		Description               string `json:"description,omitempty"`
-		ProfileImageURL           string `json:"profile_image_url,omitempty"`
-		ProfileImageURLLarge      string `json:"profile_image_url_large,omitempty"`
-		URL                       string `json:"url,omitempty"`
-		Protected                 bool   `json:"protected,omitempty"`
-		FollowersCount            int64  `json:"followers_count,omitempty"`
-		FriendsCount              int64  `json:"friends_count,omitempty"`
-		FavouritesCount           int64  `json:"favourites_count,omitempty"`
-		StatusesCount             int64  `json:"statuses_count,omitempty"`
-		Following                 bool   `json:"following,omitempty"`
-		Notifications             bool   `json:"notifications,omitempty"`
-		CreatedAt                 string `json:"created_at,omitempty"`
-		UtcOffset                 int64  `json:"utc_offset,omitempty"`
-		ProfileBackgroundColor    string `json:"profile_background_color,omitempty"`
-		ProfileTextColor          string `json:"profile_text_color,omitempty"`
-		ProfileLinkColor          string `json:"profile_link_color,omitempty"`
-		ProfileSidebarFillColor   string `json:"profile_sidebar_fill_color,omitempty"`
-		ProfileSidebarBorderColor string `json:"profile_sidebar_border_color,omitempty"`
-		ProfileBackgroundImageURL string `json:"profile_background_image_url,omitempty"`
-		ProfileBackgroundTile     bool   `json:"profile_background_tile,omitempty"`
-	} `json:"user,omitempty"`
-	Photo struct {
-		Imageurl string `json:"imageurl,omitempty"`
-		Thumburl string `json:"thumburl,omitempty"`
-		Largeurl string `json:"largeurl,omitempty"`
-	} `json:"photo,omitempty"`
+	User                *UserResult   `json:"user,omitempty"`
+	Photo               *Photo        `json:"photo,omitempty"`
+}
+
+// GetUser returns the author of the status. It is nil-safe, so the fields
+// of the author can be read with e.g. s.GetUser().GetScreenName() even if
+// the status or its author is missing.
+func (s *StatusResult) GetUser() *UserResult {
+	if s == nil {
+		return nil
+	}
+
+	return s.User
+}
+
+// GetPhoto returns the photo of the status, or nil if there is none
+func (s *StatusResult) GetPhoto() *Photo {
+	if s == nil {
+		return nil
+	}
+
+	return s.Photo
+}
+
+// GetRepostUser returns the author of the reposted status, or nil if the
+// status is not a repost or the reposted status is missing
+func (s *StatusResult) GetRepostUser() *UserResult {
+	if s == nil {
+		return nil
+	}
+
+	return s.RepostStatus.GetUser()
+}
+
+// LocalCreatedAt returns the creation time of the status in the time zone
+// of its author, from the UTC offset of the author, or the zero time if it
+// is unknown
+func (s *StatusResult) LocalCreatedAt() time.Time {
+	if s == nil || s.CreatedAt == nil {
+		return time.Time{}
+	}
+
+	return s.CreatedAt.In(s.GetUser().TimeZone())
 }
 
 // StatusesOptParams specifies the optional params for statuses API
@@ -87,6 +99,12 @@ type StatusesOptParams struct {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.update
 func (s *StatusesService) Update(status string, opt *StatusesOptParams) (*StatusResult, *string, error) {
+	return s.UpdateContext(context.Background(), status, opt)
+}
+
+// UpdateContext is the same as Update, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *StatusesService) UpdateContext(ctx context.Context, status string, opt *StatusesOptParams) (*StatusResult, *string, error) {
 	u := fmt.Sprintf("statuses/update.json")
 	params := url.Values{
 		"status": []string{status},
@@ -116,7 +134,7 @@ func (s *StatusesService) Update(status string, opt *StatusesOptParams) (*Status
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -124,7 +142,7 @@ func (s *StatusesService) Update(status string, opt *StatusesOptParams) (*Status
 	newStatus := new(StatusResult)
 	resp, err := s.client.Do(req, newStatus)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newStatus, resp.BodyStrPtr, nil
@@ -136,6 +154,12 @@ func (s *StatusesService) Update(status string, opt *StatusesOptParams) (*Status
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.show
 func (s *StatusesService) Show(ID string, opt *StatusesOptParams) (*StatusResult, *string, error) {
+	return s.ShowContext(context.Background(), ID, opt)
+}
+
+// ShowContext is the same as Show, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *StatusesService) ShowContext(ctx context.Context, ID string, opt *StatusesOptParams) (*StatusResult, *string, error) {
 	u := fmt.Sprintf("statuses/show.json")
 	params := url.Values{}
 	params.Add("id", ID)
@@ -152,7 +176,7 @@ func (s *StatusesService) Show(ID string, opt *StatusesOptParams) (*StatusResult
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -160,7 +184,7 @@ func (s *StatusesService) Show(ID string, opt *StatusesOptParams) (*StatusResult
 	newStatus := new(StatusResult)
 	resp, err := s.client.Do(req, newStatus)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newStatus, resp.BodyStrPtr, nil
@@ -173,6 +197,12 @@ func (s *StatusesService) Show(ID string, opt *StatusesOptParams) (*StatusResult
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.home-timeline
 func (s *StatusesService) HomeTimeline(opt *StatusesOptParams) ([]StatusResult, *string, error) {
+	return s.HomeTimelineContext(context.Background(), opt)
+}
+
+// HomeTimelineContext is the same as HomeTimeline, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *StatusesService) HomeTimelineContext(ctx context.Context, opt *StatusesOptParams) ([]StatusResult, *string, error) {
 	u := fmt.Sprintf("statuses/home_timeline.json")
 	params := url.Values{}
 
@@ -202,7 +232,7 @@ func (s *StatusesService) HomeTimeline(opt *StatusesOptParams) ([]StatusResult,
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -210,7 +240,7 @@ func (s *StatusesService) HomeTimeline(opt *StatusesOptParams) ([]StatusResult,
 	newStatuses := new([]StatusResult)
 	resp, err := s.client.Do(req, newStatuses)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newStatuses, resp.BodyStrPtr, nil
@@ -220,6 +250,12 @@ func (s *StatusesService) HomeTimeline(opt *StatusesOptParams) ([]StatusResult,
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.public-timeline
 func (s *StatusesService) PublicTimeline(opt *StatusesOptParams) ([]StatusResult, *string, error) {
+	return s.PublicTimelineContext(context.Background(), opt)
+}
+
+// PublicTimelineContext is the same as PublicTimeline, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *StatusesService) PublicTimelineContext(ctx context.Context, opt *StatusesOptParams) ([]StatusResult, *string, error) {
 	u := fmt.Sprintf("statuses/public_timeline.json")
 	params := url.Values{}
 
@@ -246,7 +282,7 @@ func (s *StatusesService) PublicTimeline(opt *StatusesOptParams) ([]StatusResult
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -254,7 +290,7 @@ func (s *StatusesService) PublicTimeline(opt *StatusesOptParams) ([]StatusResult
 	newStatuses := new([]StatusResult)
 	resp, err := s.client.Do(req, newStatuses)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newStatuses, resp.BodyStrPtr, nil
@@ -267,6 +303,12 @@ func (s *StatusesService) PublicTimeline(opt *StatusesOptParams) ([]StatusResult
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.user-timeline
 func (s *StatusesService) UserTimeline(opt *StatusesOptParams) ([]StatusResult, *string, error) {
+	return s.UserTimelineContext(context.Background(), opt)
+}
+
+// UserTimelineContext is the same as UserTimeline, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *StatusesService) UserTimelineContext(ctx context.Context, opt *StatusesOptParams) ([]StatusResult, *string, error) {
 	u := fmt.Sprintf("statuses/user_timeline.json")
 	params := url.Values{}
 
@@ -296,7 +338,7 @@ func (s *StatusesService) UserTimeline(opt *StatusesOptParams) ([]StatusResult,
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -304,7 +346,7 @@ func (s *StatusesService) UserTimeline(opt *StatusesOptParams) ([]StatusResult,
 	newStatuses := new([]StatusResult)
 	resp, err := s.client.Do(req, newStatuses)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newStatuses, resp.BodyStrPtr, nil
@@ -316,6 +358,12 @@ func (s *StatusesService) UserTimeline(opt *StatusesOptParams) ([]StatusResult,
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.context-timeline
 func (s *StatusesService) ContextTimeline(ID string, opt *StatusesOptParams) ([]StatusResult, *string, error) {
+	return s.ContextTimelineContext(context.Background(), ID, opt)
+}
+
+// ContextTimelineContext is the same as ContextTimeline, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *StatusesService) ContextTimelineContext(ctx context.Context, ID string, opt *StatusesOptParams) ([]StatusResult, *string, error) {
 	u := fmt.Sprintf("statuses/context_timeline.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -332,7 +380,7 @@ func (s *StatusesService) ContextTimeline(ID string, opt *StatusesOptParams) ([]
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -340,7 +388,7 @@ func (s *StatusesService) ContextTimeline(ID string, opt *StatusesOptParams) ([]
 	newStatuses := new([]StatusResult)
 	resp, err := s.client.Do(req, newStatuses)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newStatuses, resp.BodyStrPtr, nil
@@ -350,6 +398,12 @@ func (s *StatusesService) ContextTimeline(ID string, opt *StatusesOptParams) ([]
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.replies
 func (s *StatusesService) Replies(opt *StatusesOptParams) ([]StatusResult, *string, error) {
+	return s.RepliesContext(context.Background(), opt)
+}
+
+// RepliesContext is the same as Replies, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *StatusesService) RepliesContext(ctx context.Context, opt *StatusesOptParams) ([]StatusResult, *string, error) {
 	u := fmt.Sprintf("statuses/replies.json")
 	params := url.Values{}
 
@@ -376,7 +430,7 @@ func (s *StatusesService) Replies(opt *StatusesOptParams) ([]StatusResult, *stri
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -384,7 +438,7 @@ func (s *StatusesService) Replies(opt *StatusesOptParams) ([]StatusResult, *stri
 	newStatuses := new([]StatusResult)
 	resp, err := s.client.Do(req, newStatuses)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newStatuses, resp.BodyStrPtr, nil
@@ -394,6 +448,12 @@ func (s *StatusesService) Replies(opt *StatusesOptParams) ([]StatusResult, *stri
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.mentions
 func (s *StatusesService) Mentions(opt *StatusesOptParams) ([]StatusResult, *string, error) {
+	return s.MentionsContext(context.Background(), opt)
+}
+
+// MentionsContext is the same as Mentions, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *StatusesService) MentionsContext(ctx context.Context, opt *StatusesOptParams) ([]StatusResult, *string, error) {
 	u := fmt.Sprintf("statuses/mentions.json")
 	params := url.Values{}
 
@@ -420,7 +480,7 @@ func (s *StatusesService) Mentions(opt *StatusesOptParams) ([]StatusResult, *str
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -428,7 +488,7 @@ func (s *StatusesService) Mentions(opt *StatusesOptParams) ([]StatusResult, *str
 	newStatuses := new([]StatusResult)
 	resp, err := s.client.Do(req, newStatuses)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newStatuses, resp.BodyStrPtr, nil
@@ -440,6 +500,12 @@ func (s *StatusesService) Mentions(opt *StatusesOptParams) ([]StatusResult, *str
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.destroy
 func (s *StatusesService) Destroy(ID string, opt *StatusesOptParams) (*StatusResult, *string, error) {
+	return s.DestroyContext(context.Background(), ID, opt)
+}
+
+// DestroyContext is the same as Destroy, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *StatusesService) DestroyContext(ctx context.Context, ID string, opt *StatusesOptParams) (*StatusResult, *string, error) {
 	u := fmt.Sprintf("statuses/destroy.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -454,7 +520,7 @@ func (s *StatusesService) Destroy(ID string, opt *StatusesOptParams) (*StatusRes
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -462,7 +528,7 @@ func (s *StatusesService) Destroy(ID string, opt *StatusesOptParams) (*StatusRes
 	newStatus := new(StatusResult)
 	resp, err := s.client.Do(req, newStatus)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newStatus, resp.BodyStrPtr, nil
@@ -475,6 +541,12 @@ func (s *StatusesService) Destroy(ID string, opt *StatusesOptParams) (*StatusRes
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.followers
 func (s *StatusesService) Followers(opt *StatusesOptParams) ([]UserResult, *string, error) {
+	return s.FollowersContext(context.Background(), opt)
+}
+
+// FollowersContext is the same as Followers, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *StatusesService) FollowersContext(ctx context.Context, opt *StatusesOptParams) ([]UserResult, *string, error) {
 	u := fmt.Sprintf("statuses/followers.json")
 	params := url.Values{}
 
@@ -498,7 +570,7 @@ func (s *StatusesService) Followers(opt *StatusesOptParams) ([]UserResult, *stri
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -506,7 +578,7 @@ func (s *StatusesService) Followers(opt *StatusesOptParams) ([]UserResult, *stri
 	newUsers := new([]UserResult)
 	resp, err := s.client.Do(req, newUsers)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newUsers, resp.BodyStrPtr, nil
@@ -519,6 +591,12 @@ func (s *StatusesService) Followers(opt *StatusesOptParams) ([]UserResult, *stri
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.friends
 func (s *StatusesService) Friends(opt *StatusesOptParams) ([]UserResult, *string, error) {
+	return s.FriendsContext(context.Background(), opt)
+}
+
+// FriendsContext is the same as Friends, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *StatusesService) FriendsContext(ctx context.Context, opt *StatusesOptParams) ([]UserResult, *string, error) {
 	u := fmt.Sprintf("statuses/friends.json")
 	params := url.Values{}
 
@@ -542,7 +620,7 @@ func (s *StatusesService) Friends(opt *StatusesOptParams) ([]UserResult, *string
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -550,7 +628,7 @@ func (s *StatusesService) Friends(opt *StatusesOptParams) ([]UserResult, *string
 	newUsers := new([]UserResult)
 	resp, err := s.client.Do(req, newUsers)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newUsers, resp.BodyStrPtr, nil
@@ -1,6 +1,8 @@
 package fanfou
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -504,3 +506,69 @@ func TestStatusesService_Friends(t *testing.T) {
 		t.Errorf("statuses.friends returned %+v, want %+v", users, want)
 	}
 }
+
+func TestStatusesService_HomeTimelineContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/statuses/home_timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, err := fmt.Fprint(w, `[{"id": "test_id"}]`)
+		if err != nil {
+			t.Errorf("statuses.home_timeline mock server error: %+v", err)
+		}
+	})
+
+	statuses, _, err := client.Statuses.HomeTimelineContext(context.Background(), nil)
+	if err != nil {
+		t.Errorf("statuses.home_timeline returned error: %v", err)
+	}
+
+	want := []StatusResult{{ID: "test_id"}}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Errorf("statuses.home_timeline returned %+v, want %+v", statuses, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = client.Statuses.HomeTimelineContext(ctx, nil)
+	if err != context.Canceled {
+		t.Errorf("statuses.home_timeline with canceled context returned %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestStatusResult_users(t *testing.T) {
+	data := `{"id": "test_id", "user": {"id": "test_user_id", "unique_id": "test_unique_id", "sign_name": "test_sign_name"}, "photo": {"largeurl": "test_largeurl"}, "repost_status": {"id": "test_repost_id", "user": {"id": "test_repost_user_id", "screen_name": "test_screen_name"}}}`
+
+	status := new(StatusResult)
+	if err := json.Unmarshal([]byte(data), status); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	wantUser := &UserResult{ID: "test_user_id", UniqueID: "test_unique_id", SignName: "test_sign_name"}
+	if !reflect.DeepEqual(status.GetUser(), wantUser) {
+		t.Errorf("StatusResult.GetUser() = %+v, want %+v", status.GetUser(), wantUser)
+	}
+
+	if got := status.GetPhoto().Largeurl; got != "test_largeurl" {
+		t.Errorf("StatusResult.GetPhoto().Largeurl = %v, want %v", got, "test_largeurl")
+	}
+
+	if got := status.GetRepostUser().GetScreenName(); got != "test_screen_name" {
+		t.Errorf("StatusResult.GetRepostUser().GetScreenName() = %v, want %v", got, "test_screen_name")
+	}
+
+	var missing *StatusResult
+	if missing.GetUser() != nil || missing.GetPhoto() != nil || missing.GetRepostUser() != nil {
+		t.Errorf("getters of a nil StatusResult returned non-nil values")
+	}
+
+	if got := missing.GetUser().GetScreenName(); got != "" {
+		t.Errorf("GetScreenName() of a missing user = %v, want empty", got)
+	}
+
+	if got := status.RepostStatus.GetRepostUser(); got != nil {
+		t.Errorf("GetRepostUser() of a status which is not a repost = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,231 @@
+package fanfou
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Default intervals between the polls of a Stream
+const (
+	DefaultMinPollInterval = 15 * time.Second
+	DefaultMaxPollInterval = 2 * time.Minute
+)
+
+// TimelineFunc fetches a page of a timeline, e.g.
+// StatusesService.HomeTimelineContext or StatusesService.MentionsContext
+type TimelineFunc func(ctx context.Context, opt *StatusesOptParams) ([]StatusResult, *string, error)
+
+// WatchOptParams specifies the optional params for watching a timeline
+type WatchOptParams struct {
+	// Statuses are the params of every poll, e.g. the ID of the user or the
+	// Count per page. SinceID is the ID of the newest status already seen:
+	// if it is not set, the statuses already in the timeline when the
+	// stream starts are not delivered.
+	Statuses *StatusesOptParams
+
+	// MinInterval is the interval between polls while new statuses keep
+	// coming, DefaultMinPollInterval if not set
+	MinInterval time.Duration
+
+	// MaxInterval is the interval the polls slow down to while nothing new
+	// comes or the polls fail, DefaultMaxPollInterval if not set
+	MaxInterval time.Duration
+
+	// OnError is called with the errors of the polls, which do not stop
+	// the stream
+	OnError func(error)
+}
+
+// A Stream delivers the new statuses of a timeline, polling it until its
+// context is done
+type Stream struct {
+	// C delivers the new statuses in chronological order. It is closed once
+	// the context of the stream is done.
+	C <-chan StatusResult
+
+	timeline TimelineFunc
+	opt      WatchOptParams
+	params   StatusesOptParams
+
+	// primed is set once the statuses already in the timeline are known,
+	// after the first successful poll or if SinceID is set
+	primed bool
+
+	mu      sync.Mutex
+	sinceID string
+}
+
+// Watch shall poll the timeline and deliver its new statuses on the C
+// channel of the returned Stream, until ctx is done. The polls speed up
+// to opt.MinInterval while new statuses come, and slow down to
+// opt.MaxInterval otherwise. When more statuses than a page came between
+// two polls, the older pages are fetched too so that none is missed.
+func Watch(ctx context.Context, timeline TimelineFunc, opt *WatchOptParams) *Stream {
+	c := make(chan StatusResult)
+
+	s := &Stream{C: c, timeline: timeline}
+	if opt != nil {
+		s.opt = *opt
+	}
+	if s.opt.Statuses != nil {
+		s.params = *s.opt.Statuses
+	}
+	if s.opt.MinInterval <= 0 {
+		s.opt.MinInterval = DefaultMinPollInterval
+	}
+	if s.opt.MaxInterval < s.opt.MinInterval {
+		s.opt.MaxInterval = max(DefaultMaxPollInterval, s.opt.MinInterval)
+	}
+	s.params.Page, s.params.MaxID = 0, ""
+	s.params.Count = pageCount(s.params.Count)
+	s.sinceID = s.params.SinceID
+	s.primed = s.sinceID != ""
+
+	go s.run(ctx, c)
+
+	return s
+}
+
+// WatchHomeTimeline shall watch the home timeline, see Watch
+func (s *StatusesService) WatchHomeTimeline(ctx context.Context, opt *WatchOptParams) *Stream {
+	return Watch(ctx, s.HomeTimelineContext, opt)
+}
+
+// WatchMentions shall watch the mentions of the current user, see Watch
+func (s *StatusesService) WatchMentions(ctx context.Context, opt *WatchOptParams) *Stream {
+	return Watch(ctx, s.MentionsContext, opt)
+}
+
+// SinceID returns the ID of the newest status delivered, which can be used
+// as the SinceID of a later stream to resume watching
+func (s *Stream) SinceID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sinceID
+}
+
+// run polls the timeline until ctx is done
+func (s *Stream) run(ctx context.Context, c chan<- StatusResult) {
+	defer close(c)
+
+	interval := s.opt.MinInterval
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		statuses, err := s.poll(ctx)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return
+			}
+			if s.opt.OnError != nil {
+				s.opt.OnError(err)
+			}
+			interval = s.opt.MaxInterval
+		case len(statuses) > 0:
+			interval = max(interval/2, s.opt.MinInterval)
+		default:
+			interval = min(interval*2, s.opt.MaxInterval)
+		}
+
+		for _, status := range statuses {
+			select {
+			case c <- status:
+			case <-ctx.Done():
+				return
+			}
+
+			s.mu.Lock()
+			s.sinceID = status.ID
+			s.mu.Unlock()
+		}
+
+		timer.Reset(interval)
+	}
+}
+
+// poll fetches the statuses newer than the newest one seen, in
+// chronological order. The first poll only records the newest status of
+// the timeline, if any, unless SinceID was set.
+func (s *Stream) poll(ctx context.Context) ([]StatusResult, error) {
+	params := s.params
+	params.SinceID = s.SinceID()
+
+	fetch := func(ctx context.Context, maxID string) ([]StatusResult, error) {
+		p := params
+		p.MaxID = maxID
+		page, _, err := s.timeline(ctx, &p)
+		return page, err
+	}
+
+	page, err := fetch(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.primed {
+		if len(page) > 0 {
+			s.mu.Lock()
+			s.sinceID = page[0].ID
+			s.mu.Unlock()
+		}
+		s.primed = true
+		return nil, nil
+	}
+
+	statuses, err := fillGap(ctx, page, params.Count, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(statuses)
+
+	return statuses, nil
+}
+
+// fillGap returns the items of page, the newest page of the items newer
+// than a since_id, followed by the older ones. A full page may not hold all
+// of them, so the older pages are fetched with fetch, up to the first one
+// shorter than count or without anything new.
+func fillGap[T timelineItem](ctx context.Context, page []T, count int64, fetch func(ctx context.Context, maxID string) ([]T, error)) ([]T, error) {
+	items := page
+	seen := make(map[string]bool, len(page))
+	for i := range page {
+		id, _ := itemKey(&page[i])
+		seen[id] = true
+	}
+
+	for int64(len(page)) >= count {
+		maxID, _ := itemKey(&page[len(page)-1])
+
+		var err error
+		page, err = fetch(ctx, maxID)
+		if err != nil {
+			return nil, err
+		}
+
+		fresh := 0
+		for i := range page {
+			if id, _ := itemKey(&page[i]); !seen[id] {
+				seen[id] = true
+				items = append(items, page[i])
+				fresh++
+			}
+		}
+		if fresh == 0 {
+			break
+		}
+	}
+
+	return items, nil
+}
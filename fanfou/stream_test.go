@@ -0,0 +1,194 @@
+package fanfou
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testTimeline is a timeline of statuses with IDs from "1" to n, served
+// with since_id and max_id as Fanfou does
+type testTimeline struct {
+	mu    sync.Mutex
+	n     int
+	fail  bool
+	polls int
+}
+
+// served returns the number of requests served
+func (tl *testTimeline) served() int {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	return tl.polls
+}
+
+func (tl *testTimeline) post(n int) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	tl.n += n
+}
+
+func (tl *testTimeline) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	tl.polls++
+	if tl.fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"error": "test error"}`)
+		return
+	}
+
+	sinceID, _ := strconv.Atoi(r.FormValue("since_id"))
+	count, _ := strconv.Atoi(r.FormValue("count"))
+	newest := tl.n
+	if maxID := r.FormValue("max_id"); maxID != "" {
+		newest, _ = strconv.Atoi(maxID)
+	}
+
+	statuses := []StatusResult{}
+	for id := newest; id > sinceID && len(statuses) < count; id-- {
+		statuses = append(statuses, StatusResult{ID: strconv.Itoa(id)})
+	}
+
+	json.NewEncoder(w).Encode(statuses)
+}
+
+// receive returns the next n statuses of the stream
+func receive(t *testing.T, s *Stream, n int) []string {
+	var ids []string
+	for len(ids) < n {
+		select {
+		case status, ok := <-s.C:
+			if !ok {
+				t.Fatalf("stream closed after %v, want %d statuses", ids, n)
+			}
+			ids = append(ids, status.ID)
+		case <-time.After(time.Second):
+			t.Fatalf("stream timed out after %v, want %d statuses", ids, n)
+		}
+	}
+
+	return ids
+}
+
+func TestWatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tl := &testTimeline{n: 3}
+	mux.Handle("/statuses/home_timeline.json", tl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := client.Statuses.WatchHomeTimeline(ctx, &WatchOptParams{
+		Statuses:    &StatusesOptParams{Count: 2},
+		MinInterval: time.Millisecond,
+		MaxInterval: 5 * time.Millisecond,
+	})
+
+	// The statuses already there are not delivered
+	for stream.SinceID() != "3" {
+		time.Sleep(time.Millisecond)
+	}
+
+	tl.post(5)
+
+	want := []string{"4", "5", "6", "7", "8"}
+	if got := receive(t, stream, 5); !reflect.DeepEqual(got, want) {
+		t.Errorf("stream delivered %v, want %v", got, want)
+	}
+
+	tl.post(1)
+	if got := receive(t, stream, 1); !reflect.DeepEqual(got, []string{"9"}) {
+		t.Errorf("stream delivered %v, want %v", got, []string{"9"})
+	}
+
+	cancel()
+	for range stream.C {
+	}
+
+	if got := stream.SinceID(); got != "9" {
+		t.Errorf("stream.SinceID() = %v, want %v", got, "9")
+	}
+}
+
+func TestWatch_emptyStart(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tl := &testTimeline{}
+	mux.Handle("/statuses/home_timeline.json", tl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := client.Statuses.WatchHomeTimeline(ctx, &WatchOptParams{
+		MinInterval: time.Millisecond,
+		MaxInterval: time.Millisecond,
+	})
+
+	// The timeline is empty when the stream starts, so every status posted
+	// afterwards is new
+	for tl.served() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	tl.post(2)
+
+	want := []string{"1", "2"}
+	if got := receive(t, stream, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("stream delivered %v, want %v", got, want)
+	}
+}
+
+func TestWatch_sinceID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tl := &testTimeline{n: 4, fail: true}
+	mux.Handle("/statuses/mentions.json", tl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, 100)
+	stream := client.Statuses.WatchMentions(ctx, &WatchOptParams{
+		Statuses:    &StatusesOptParams{SinceID: "2"},
+		MinInterval: time.Millisecond,
+		MaxInterval: time.Millisecond,
+		OnError: func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		},
+	})
+
+	select {
+	case err := <-errs:
+		if _, ok := err.(*ErrorResponse); !ok {
+			t.Errorf("stream reported %v, want an ErrorResponse", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("stream reported no error")
+	}
+
+	tl.mu.Lock()
+	tl.fail = false
+	tl.mu.Unlock()
+
+	want := []string{"3", "4"}
+	if got := receive(t, stream, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("stream delivered %v, want %v", got, want)
+	}
+}
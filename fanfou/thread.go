@@ -0,0 +1,269 @@
+package fanfou
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// threadURLPattern matches the URLs of a text, which are never split
+var threadURLPattern = regexp.MustCompile(`https?://[^\s　]+`)
+
+// Break levels of the positions a text can be split at, from the worst to
+// the best
+const (
+	breakNone = iota
+	breakCharacter
+	breakWord
+	breakClause
+	breakSentence
+)
+
+// ThreadOptParams specifies the optional params for posting threads
+type ThreadOptParams struct {
+	// InReplyToStatusID is the ID of the status the first part replies to
+	InReplyToStatusID string
+
+	// InReplyToUserID is the ID of the author of the status the first part
+	// replies to
+	InReplyToUserID string
+
+	// SkipNumbering leaves the parts unnumbered, instead of suffixing them
+	// with e.g. " (1/3)"
+	SkipNumbering bool
+
+	Source   string
+	Location string
+}
+
+// ThreadError is returned when a part of a thread fails to be posted
+type ThreadError struct {
+	// Part is the index of the part which failed, starting at 0
+	Part int
+
+	// Err is the error the part failed with
+	Err error
+
+	// RollbackErr is the error of the deletion of the parts posted before
+	// the failure, if any of them could not be deleted
+	RollbackErr error
+}
+
+// Error implements the error interface
+func (e *ThreadError) Error() string {
+	msg := fmt.Sprintf("posting part %d of thread: %v", e.Part+1, e.Err)
+	if e.RollbackErr != nil {
+		msg += fmt.Sprintf(" (rollback failed: %v)", e.RollbackErr)
+	}
+
+	return msg
+}
+
+// Unwrap returns the error the part failed with
+func (e *ThreadError) Unwrap() error {
+	return e.Err
+}
+
+// SplitThread splits text into parts no longer than MaxStatusLength, at
+// sentence boundaries if possible, then clause or word boundaries, then
+// between CJK characters. URLs are never split unless they are too long to
+// fit a part on their own. If numbered, the parts are suffixed with their
+// number, e.g. " (1/3)", unless the text fits a single part.
+func SplitThread(text string, numbered bool) []string {
+	text = strings.TrimSpace(text)
+	if StatusLength(text) <= MaxStatusLength {
+		if text == "" {
+			return nil
+		}
+		return []string{text}
+	}
+
+	if !numbered {
+		return splitText(text, MaxStatusLength)
+	}
+
+	// The room taken by the numbers depends on the count of parts, which
+	// depends on the room left: retry until the count of digits is stable
+	digits := 1
+	for {
+		parts := splitText(text, MaxStatusLength-numberWidth(digits))
+
+		if n := len(strconv.Itoa(len(parts))); n > digits {
+			digits = n
+			continue
+		}
+
+		for i := range parts {
+			parts[i] += fmt.Sprintf(" (%d/%d)", i+1, len(parts))
+		}
+
+		return parts
+	}
+}
+
+// numberWidth returns the length of the longest suffix numbering a part,
+// e.g. " (12/12)", given the count of digits of the count of parts
+func numberWidth(digits int) int {
+	return len(" (/)") + 2*digits
+}
+
+// splitText splits text into parts of at most size characters
+func splitText(text string, size int) []string {
+	runes := []rune(text)
+
+	// Nothing can be split inside a URL
+	inURL := make([]bool, len(runes)+1)
+	for _, loc := range threadURLPattern.FindAllStringIndex(text, -1) {
+		start := len([]rune(text[:loc[0]]))
+		end := start + len([]rune(text[loc[0]:loc[1]]))
+		for i := start + 1; i < end; i++ {
+			inURL[i] = true
+		}
+	}
+
+	var parts []string
+	start := 0
+
+	for start < len(runes) {
+		if len(runes)-start <= size {
+			parts = appendPart(parts, runes[start:])
+			break
+		}
+
+		end := start + size
+		cut, best := end, breakNone
+
+		for i := end; i > start; i-- {
+			level := breakLevel(runes, i)
+			if inURL[i] {
+				level = breakNone
+			}
+
+			// Sentences and clauses shall not leave too short a part behind
+			if level >= breakClause && i-start < size/3 {
+				level = breakWord
+			}
+
+			if level > best {
+				cut, best = i, level
+			}
+			if best == breakSentence {
+				break
+			}
+		}
+
+		parts = appendPart(parts, runes[start:cut])
+
+		start = cut
+		for start < len(runes) && unicode.IsSpace(runes[start]) {
+			start++
+		}
+	}
+
+	return parts
+}
+
+// appendPart appends the trimmed part to parts, unless it is blank
+func appendPart(parts []string, part []rune) []string {
+	if s := strings.TrimSpace(string(part)); s != "" {
+		return append(parts, s)
+	}
+
+	return parts
+}
+
+// breakLevel returns how good a position is to split the text at, i.e.
+// between runes[i-1] and runes[i]
+func breakLevel(runes []rune, i int) int {
+	prev, next := runes[i-1], runes[i]
+
+	switch {
+	case strings.ContainsRune("。！？!?…\n", prev):
+		return breakSentence
+	case prev == '.' && unicode.IsSpace(next):
+		return breakSentence
+	case strings.ContainsRune("，、；：,;:）)」』", prev):
+		return breakClause
+	case unicode.IsSpace(prev) || unicode.IsSpace(next):
+		return breakWord
+	case isCJK(prev) || isCJK(next):
+		return breakCharacter
+	}
+
+	return breakNone
+}
+
+// isCJK reports whether r is a Chinese, Japanese or Korean character, which
+// can be split anywhere
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		unicode.In(r, unicode.Punct) && r >= 0x3000
+}
+
+// PostThread shall split text with SplitThread and post the parts in
+// order, every part replying to the previous one. If a part fails to be
+// posted, the parts already posted are deleted and a *ThreadError is
+// returned.
+//
+// Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/statuses.update
+func (s *StatusesService) PostThread(text string, opt *ThreadOptParams) ([]StatusResult, error) {
+	return s.PostThreadContext(context.Background(), text, opt)
+}
+
+// PostThreadContext is the same as PostThread, except that the requests
+// are bound to ctx and aborted once ctx is done. The parts already posted
+// are deleted even if ctx is done.
+func (s *StatusesService) PostThreadContext(ctx context.Context, text string, opt *ThreadOptParams) ([]StatusResult, error) {
+	if opt == nil {
+		opt = &ThreadOptParams{}
+	}
+
+	parts := SplitThread(text, !opt.SkipNumbering)
+	if len(parts) == 0 {
+		return nil, ErrEmptyStatus
+	}
+
+	draft := &Draft{
+		InReplyToStatusID: opt.InReplyToStatusID,
+		InReplyToUserID:   opt.InReplyToUserID,
+		Source:            opt.Source,
+		Location:          opt.Location,
+	}
+
+	posted := make([]StatusResult, 0, len(parts))
+	for i, part := range parts {
+		draft.Text = part
+
+		status, _, err := s.PublishContext(ctx, draft)
+		if err != nil {
+			return nil, &ThreadError{
+				Part:        i,
+				Err:         err,
+				RollbackErr: s.rollback(context.WithoutCancel(ctx), posted),
+			}
+		}
+
+		posted = append(posted, *status)
+		draft.InReplyToStatusID = status.ID
+		draft.InReplyToUserID = status.GetUser().GetID()
+	}
+
+	return posted, nil
+}
+
+// rollback deletes the posted statuses, the latest first, and returns the
+// first error met
+func (s *StatusesService) rollback(ctx context.Context, posted []StatusResult) error {
+	var firstErr error
+
+	for i := len(posted) - 1; i >= 0; i-- {
+		if _, _, err := s.DestroyContext(ctx, posted[i].ID, nil); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,169 @@
+package fanfou
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitThread_short(t *testing.T) {
+	if got := SplitThread("  hello  ", true); !reflect.DeepEqual(got, []string{"hello"}) {
+		t.Errorf("SplitThread returned %q, want %q", got, []string{"hello"})
+	}
+
+	if got := SplitThread("   ", true); len(got) != 0 {
+		t.Errorf("SplitThread of a blank text returned %q, want no part", got)
+	}
+}
+
+func TestSplitThread_sentences(t *testing.T) {
+	first := strings.Repeat("饭", 80) + "。"
+	second := strings.Repeat("否", 80) + "！"
+
+	got := SplitThread(first+second, true)
+	want := []string{first + " (1/2)", second + " (2/2)"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitThread returned %q, want %q", got, want)
+	}
+}
+
+func TestSplitThread_words(t *testing.T) {
+	text := strings.Repeat("word ", 60)
+
+	got := SplitThread(text, false)
+	if len(got) != 3 {
+		t.Fatalf("SplitThread returned %d parts, want %d", len(got), 3)
+	}
+
+	for _, part := range got {
+		if StatusLength(part) > MaxStatusLength {
+			t.Errorf("SplitThread returned a part of length %d: %q", StatusLength(part), part)
+		}
+		if strings.Contains(" "+part+" ", " wo ") || strings.HasSuffix(part, "wor") {
+			t.Errorf("SplitThread split a word in %q", part)
+		}
+	}
+
+	if joined := strings.Join(got, " "); joined != strings.TrimSpace(text) {
+		t.Errorf("SplitThread parts join to %q, want %q", joined, strings.TrimSpace(text))
+	}
+}
+
+func TestSplitThread_url(t *testing.T) {
+	u := "https://example.com/" + strings.Repeat("a", 40)
+	text := strings.Repeat("饭", 120) + u + " 否"
+
+	got := SplitThread(text, false)
+	want := []string{strings.Repeat("饭", 120), u + " 否"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitThread returned %q, want %q", got, want)
+	}
+}
+
+func TestSplitThread_numbering(t *testing.T) {
+	got := SplitThread(strings.Repeat("饭", 1400), true)
+
+	if len(got) != 11 {
+		t.Fatalf("SplitThread returned %d parts, want %d", len(got), 11)
+	}
+
+	for i, part := range got {
+		if StatusLength(part) > MaxStatusLength {
+			t.Errorf("SplitThread returned a part of length %d: %q", StatusLength(part), part)
+		}
+		if suffix := fmt.Sprintf(" (%d/11)", i+1); !strings.HasSuffix(part, suffix) {
+			t.Errorf("SplitThread part %d is %q, want suffix %q", i, part, suffix)
+		}
+	}
+}
+
+func TestStatusesService_PostThread(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var texts, replies []string
+	mux.HandleFunc("/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		texts = append(texts, r.FormValue("status"))
+		replies = append(replies, r.FormValue("in_reply_to_status_id"))
+
+		_, err := fmt.Fprintf(w, `{"id": "test_id_%d", "user": {"id": "test_user_id"}}`, len(texts))
+		if err != nil {
+			t.Errorf("statuses.update mock server error: %+v", err)
+		}
+	})
+
+	text := strings.Repeat("饭", 100) + "。" + strings.Repeat("否", 100)
+
+	statuses, err := client.Statuses.PostThread(text, &ThreadOptParams{InReplyToStatusID: "test_id_0", InReplyToUserID: "test_user_id"})
+	if err != nil {
+		t.Fatalf("statuses.post_thread returned error: %v", err)
+	}
+
+	if len(statuses) != 2 || statuses[1].ID != "test_id_2" {
+		t.Errorf("statuses.post_thread returned %+v, want 2 statuses", statuses)
+	}
+
+	wantTexts := SplitThread(text, true)
+	if !reflect.DeepEqual(texts, wantTexts) {
+		t.Errorf("statuses.post_thread posted %q, want %q", texts, wantTexts)
+	}
+
+	wantReplies := []string{"test_id_0", "test_id_1"}
+	if !reflect.DeepEqual(replies, wantReplies) {
+		t.Errorf("statuses.post_thread replied to %q, want %q", replies, wantReplies)
+	}
+}
+
+func TestStatusesService_PostThreadRollback(t *testing.T) {
+	setup()
+	defer teardown()
+
+	posted := 0
+	mux.HandleFunc("/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		posted++
+		if posted == 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": "test error"}`)
+			return
+		}
+
+		_, err := fmt.Fprintf(w, `{"id": "test_id_%d"}`, posted)
+		if err != nil {
+			t.Errorf("statuses.update mock server error: %+v", err)
+		}
+	})
+
+	var destroyed []string
+	mux.HandleFunc("/statuses/destroy.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		destroyed = append(destroyed, r.FormValue("id"))
+		fmt.Fprint(w, `{}`)
+	})
+
+	_, err := client.Statuses.PostThread(strings.Repeat("饭", 400), nil)
+
+	var threadErr *ThreadError
+	if !errors.As(err, &threadErr) {
+		t.Fatalf("statuses.post_thread returned %v, want a ThreadError", err)
+	}
+
+	if threadErr.Part != 2 || threadErr.RollbackErr != nil {
+		t.Errorf("statuses.post_thread returned %+v, want part %d without rollback error", threadErr, 2)
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Errorf("statuses.post_thread returned %v, want it to wrap an ErrorResponse", err)
+	}
+
+	want := []string{"test_id_2", "test_id_1"}
+	if !reflect.DeepEqual(destroyed, want) {
+		t.Errorf("statuses.post_thread destroyed %q, want %q", destroyed, want)
+	}
+}
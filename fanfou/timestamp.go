@@ -0,0 +1,121 @@
+package fanfou
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+)
+
+// TimeLayout is the layout of the times returned by Fanfou, e.g.
+// "Thu Nov 17 03:45:20 +0000 2011"
+const TimeLayout = time.RubyDate
+
+// timeLayouts lists the layouts tried when parsing a time, the one of
+// Fanfou first
+var timeLayouts = []string{
+	TimeLayout,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+}
+
+// Timestamp represents a time returned by Fanfou. It is decoded from and
+// encoded to Fanfou's format, so a result can be marshalled back to its
+// original wire format. The embedded time.Time carries the UTC offset
+// returned by Fanfou.
+//
+// The results hold a *Timestamp, which is nil if Fanfou returned no time,
+// and omitted when marshalled. A zero Timestamp is marshalled as an empty
+// string.
+type Timestamp struct {
+	time.Time
+}
+
+// GetTime returns the time, or the zero time if t is nil, e.g. as the
+// creation time of a result without one
+func (t *Timestamp) GetTime() time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return t.Time
+}
+
+// parseTimestamp parses a time in any of the known layouts, or a count of
+// seconds since the Unix epoch
+func parseTimestamp(s string) (Timestamp, error) {
+	if s == "" {
+		return Timestamp{}, nil
+	}
+
+	var err error
+	for _, layout := range timeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return newTimestamp(t), nil
+		}
+	}
+
+	if seconds, parseErr := strconv.ParseInt(s, 10, 64); parseErr == nil {
+		return Timestamp{time.Unix(seconds, 0).UTC()}, nil
+	}
+
+	return Timestamp{}, err
+}
+
+// newTimestamp returns the Timestamp of t, whose location is normalized to
+// a fixed zone so it does not depend on the local time zone
+func newTimestamp(t time.Time) Timestamp {
+	_, offset := t.Zone()
+	if offset == 0 {
+		return Timestamp{t.UTC()}
+	}
+
+	return Timestamp{t.In(time.FixedZone("", offset))}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, encoding
+// the time in Fanfou's format, or as an empty string if it is zero
+func (t Timestamp) MarshalText() ([]byte, error) {
+	if t.IsZero() {
+		return []byte{}, nil
+	}
+
+	return []byte(t.Format(TimeLayout)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (t *Timestamp) UnmarshalText(data []byte) error {
+	parsed, err := parseTimestamp(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(strconv.Quote(string(text))), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Besides
+// strings, null and numbers of seconds since the Unix epoch are accepted.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	s, err := strconv.Unquote(string(data))
+	if err != nil {
+		s = string(data)
+	}
+
+	return t.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,120 @@
+package fanfou
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mustParseTimestamp returns the Timestamp of a time in Fanfou's format
+func mustParseTimestamp(s string) *Timestamp {
+	t, err := parseTimestamp(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return &t
+}
+
+func TestTimestamp_unmarshalJSON(t *testing.T) {
+	tests := map[string]time.Time{
+		`"Thu Nov 17 03:45:20 +0000 2011"`: time.Date(2011, 11, 17, 3, 45, 20, 0, time.UTC),
+		`"Thu Nov 17 11:45:20 +0800 2011"`: time.Date(2011, 11, 17, 3, 45, 20, 0, time.UTC),
+		`"2011-11-17T03:45:20Z"`:           time.Date(2011, 11, 17, 3, 45, 20, 0, time.UTC),
+		`1321501520`:                       time.Date(2011, 11, 17, 3, 45, 20, 0, time.UTC),
+		`""`:                               {},
+		`null`:                             {},
+	}
+
+	for data, want := range tests {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(data), &ts); err != nil {
+			t.Errorf("json.Unmarshal(%v) returned error: %v", data, err)
+			continue
+		}
+
+		if !ts.Time.Equal(want) {
+			t.Errorf("json.Unmarshal(%v) = %v, want %v", data, ts, want)
+		}
+	}
+
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`"yesterday"`), &ts); err == nil {
+		t.Errorf("json.Unmarshal(%v) returned no error", `"yesterday"`)
+	}
+}
+
+func TestTimestamp_roundTrip(t *testing.T) {
+	status := &StatusResult{}
+	data := `{"created_at":"Thu Nov 17 11:45:20 +0800 2011","id":"test_id"}`
+
+	if err := json.Unmarshal([]byte(data), status); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	if _, offset := status.CreatedAt.Zone(); offset != 8*3600 {
+		t.Errorf("CreatedAt offset = %v, want %v", offset, 8*3600)
+	}
+
+	got, err := json.Marshal(status.CreatedAt)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	if want := `"Thu Nov 17 11:45:20 +0800 2011"`; string(got) != want {
+		t.Errorf("json.Marshal returned %s, want %s", got, want)
+	}
+
+	type xmlStatus struct {
+		CreatedAt Timestamp `xml:"created_at"`
+	}
+
+	gotXML, err := xml.Marshal(xmlStatus{*status.CreatedAt})
+	if err != nil {
+		t.Fatalf("xml.Marshal returned error: %v", err)
+	}
+
+	var decoded xmlStatus
+	if err := xml.Unmarshal(gotXML, &decoded); err != nil {
+		t.Fatalf("xml.Unmarshal returned error: %v", err)
+	}
+
+	if decoded.CreatedAt != *status.CreatedAt {
+		t.Errorf("xml round trip returned %v, want %v", decoded.CreatedAt, status.CreatedAt)
+	}
+}
+
+func TestTimestamp_marshalZero(t *testing.T) {
+	got, err := json.Marshal(&StatusResult{ID: "test_id"})
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	if strings.Contains(string(got), "created_at") {
+		t.Errorf("json.Marshal of a status without a creation time returned %s, want created_at omitted", got)
+	}
+
+	got, err = json.Marshal(Timestamp{})
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	if want := `""`; string(got) != want {
+		t.Errorf("json.Marshal of a zero Timestamp returned %s, want %s", got, want)
+	}
+
+	var ts *Timestamp
+	if !ts.GetTime().IsZero() {
+		t.Errorf("GetTime of a nil Timestamp returned %v, want the zero time", ts.GetTime())
+	}
+}
+
+func TestStatusResult_LocalCreatedAt(t *testing.T) {
+	status := &StatusResult{CreatedAt: mustParseTimestamp("Thu Nov 17 03:45:20 +0000 2011")}
+	status.User = &UserResult{UtcOffset: 8 * 3600}
+
+	got := status.LocalCreatedAt()
+	if got.Hour() != 11 || !got.Equal(status.CreatedAt.Time) {
+		t.Errorf("LocalCreatedAt returned %v, want 2011-11-17 11:45:20 +0800", got)
+	}
+}
@@ -0,0 +1,318 @@
+package fanfou
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	// ErrNoToken is returned by TokenStore.Load when no token is stored
+	ErrNoToken = errors.New("fanfou: no stored token")
+
+	// ErrBadPassphrase is returned when a stored token cannot be decrypted
+	// with the passphrase of the store
+	ErrBadPassphrase = errors.New("fanfou: wrong passphrase for the stored token")
+)
+
+// tokenKeyIterations is the number of PBKDF2 iterations deriving the key of
+// an encrypted token file from its passphrase
+var tokenKeyIterations = 600000
+
+// A TokenStore persists the access token of a client, see
+// Client.AuthorizeClientWithStore
+type TokenStore interface {
+	// Load returns the stored token, or ErrNoToken if there is none
+	Load() (*AccessToken, error)
+
+	// Save stores the token, replacing the stored one if any
+	Save(token *AccessToken) error
+
+	// Delete removes the stored token, if any
+	Delete() error
+}
+
+// MemoryTokenStore is a TokenStore keeping the token in memory, which is
+// lost when the program exits
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *AccessToken
+}
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+// Load implements TokenStore
+func (s *MemoryTokenStore) Load() (*AccessToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil {
+		return nil, ErrNoToken
+	}
+
+	return copyToken(s.token), nil
+}
+
+// Save implements TokenStore
+func (s *MemoryTokenStore) Save(token *AccessToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = copyToken(token)
+
+	return nil
+}
+
+// Delete implements TokenStore
+func (s *MemoryTokenStore) Delete() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = nil
+
+	return nil
+}
+
+// FileTokenStore is a TokenStore keeping the token in a file only readable
+// by its owner, encrypted if a passphrase is set
+type FileTokenStore struct {
+	path       string
+	passphrase []byte
+}
+
+// NewFileTokenStore returns a FileTokenStore keeping the token in plain
+// text at path
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+// NewEncryptedFileTokenStore returns a FileTokenStore keeping the token at
+// path, encrypted with AES-GCM and a key derived from the passphrase
+func NewEncryptedFileTokenStore(path, passphrase string) *FileTokenStore {
+	return &FileTokenStore{path: path, passphrase: []byte(passphrase)}
+}
+
+// storedToken is the structure of a token file
+type storedToken struct {
+	Token          string            `json:"token,omitempty"`
+	Secret         string            `json:"secret,omitempty"`
+	AdditionalData map[string]string `json:"additional_data,omitempty"`
+
+	// The encrypted token, with the params of its decryption
+	Salt       []byte `json:"salt,omitempty"`
+	Iterations int    `json:"iterations,omitempty"`
+	Nonce      []byte `json:"nonce,omitempty"`
+	Ciphertext []byte `json:"ciphertext,omitempty"`
+}
+
+// Load implements TokenStore
+func (s *FileTokenStore) Load() (*AccessToken, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNoToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stored storedToken
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("fanfou: reading token file %s: %w", s.path, err)
+	}
+
+	if stored.Ciphertext == nil {
+		if s.passphrase != nil {
+			return nil, fmt.Errorf("fanfou: token file %s is not encrypted", s.path)
+		}
+		return &AccessToken{Token: stored.Token, Secret: stored.Secret, AdditionalData: stored.AdditionalData}, nil
+	}
+
+	if s.passphrase == nil {
+		return nil, fmt.Errorf("fanfou: token file %s is encrypted, a passphrase is needed", s.path)
+	}
+
+	gcm, err := newTokenCipher(s.passphrase, stored.Salt, stored.Iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := gcm.Open(nil, stored.Nonce, stored.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrBadPassphrase
+	}
+
+	var decrypted storedToken
+	if err := json.Unmarshal(plain, &decrypted); err != nil {
+		return nil, fmt.Errorf("fanfou: reading token file %s: %w", s.path, err)
+	}
+
+	return &AccessToken{Token: decrypted.Token, Secret: decrypted.Secret, AdditionalData: decrypted.AdditionalData}, nil
+}
+
+// Save implements TokenStore. The file is replaced atomically, and created
+// with the 0600 permissions.
+func (s *FileTokenStore) Save(token *AccessToken) error {
+	stored := storedToken{Token: token.Token, Secret: token.Secret, AdditionalData: token.AdditionalData}
+
+	if s.passphrase != nil {
+		plain, err := json.Marshal(stored)
+		if err != nil {
+			return err
+		}
+
+		stored = storedToken{Salt: make([]byte, 16), Iterations: tokenKeyIterations}
+		if _, err := rand.Read(stored.Salt); err != nil {
+			return err
+		}
+
+		gcm, err := newTokenCipher(s.passphrase, stored.Salt, stored.Iterations)
+		if err != nil {
+			return err
+		}
+
+		stored.Nonce = make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(stored.Nonce); err != nil {
+			return err
+		}
+		stored.Ciphertext = gcm.Seal(nil, stored.Nonce, plain, nil)
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	// The temporary file is created with the 0600 permissions, and only
+	// renamed once fully written
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// Delete implements TokenStore
+func (s *FileTokenStore) Delete() error {
+	err := os.Remove(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// newTokenCipher returns the AES-GCM cipher of a token file
+func newTokenCipher(passphrase, salt []byte, iterations int) (cipher.AEAD, error) {
+	if iterations <= 0 {
+		return nil, errors.New("fanfou: invalid token file")
+	}
+
+	block, err := aes.NewCipher(pbkdf2.Key(passphrase, salt, iterations, 32, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// copyToken returns a deep copy of the token
+func copyToken(token *AccessToken) *AccessToken {
+	c := *token
+	if token.AdditionalData != nil {
+		c.AdditionalData = make(map[string]string, len(token.AdditionalData))
+		for key, val := range token.AdditionalData {
+			c.AdditionalData[key] = val
+		}
+	}
+
+	return &c
+}
+
+// AuthFlow obtains an access token for the client, e.g. by running the
+// OAuth flow with GetRequestTokenAndURL and AuthorizeClient. It shall leave
+// the client authorized with the token.
+type AuthFlow func(ctx context.Context, c *Client) (*AccessToken, error)
+
+// AuthorizeClientWithStore authorizes the client with the token of the
+// store, verified with account/verify_credentials. The flow is only run if
+// no token is stored or the stored one is rejected, which is then deleted
+// from the store, and the token it obtains is saved to the store. It
+// returns the current user.
+func (c *Client) AuthorizeClientWithStore(store TokenStore, flow AuthFlow) (*UserResult, error) {
+	return c.AuthorizeClientWithStoreContext(context.Background(), store, flow)
+}
+
+// AuthorizeClientWithStoreContext is the same as AuthorizeClientWithStore,
+// except that the requests are bound to ctx and aborted once ctx is done
+func (c *Client) AuthorizeClientWithStoreContext(ctx context.Context, store TokenStore, flow AuthFlow) (*UserResult, error) {
+	token, err := store.Load()
+	switch {
+	case err == nil:
+		if err := c.AuthorizeClientWithAccessTokens(token.Token, token.Secret, token.AdditionalData); err != nil {
+			return nil, err
+		}
+
+		user, _, err := c.Account.VerifyCredentialsContext(ctx, nil)
+		if err == nil {
+			return user, nil
+		}
+
+		// Only a rejected token is replaced, not one which could not be
+		// verified for another reason
+		if !errors.Is(err, ErrUnauthorized) {
+			return nil, err
+		}
+		c.logDebug(ctx, "fanfou stored token rejected", "error", err)
+
+		if err := store.Delete(); err != nil {
+			return nil, err
+		}
+
+	case !errors.Is(err, ErrNoToken):
+		return nil, err
+	}
+
+	token, err = flow(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	user, _, err := c.Account.VerifyCredentialsContext(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Save(token); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
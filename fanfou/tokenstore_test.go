@@ -0,0 +1,194 @@
+package fanfou
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testAccessToken = &AccessToken{
+	Token:          "test_token",
+	Secret:         "test_secret",
+	AdditionalData: map[string]string{"user_id": "test_user_id"},
+}
+
+func TestMemoryTokenStore(t *testing.T) {
+	store := NewMemoryTokenStore()
+
+	if _, err := store.Load(); err != ErrNoToken {
+		t.Errorf("MemoryTokenStore.Load() of an empty store returned %v, want %v", err, ErrNoToken)
+	}
+
+	if err := store.Save(testAccessToken); err != nil {
+		t.Fatalf("MemoryTokenStore.Save() returned error: %v", err)
+	}
+
+	token, err := store.Load()
+	if err != nil {
+		t.Fatalf("MemoryTokenStore.Load() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(token, testAccessToken) {
+		t.Errorf("MemoryTokenStore.Load() returned %+v, want %+v", token, testAccessToken)
+	}
+
+	if err := store.Delete(); err != nil {
+		t.Fatalf("MemoryTokenStore.Delete() returned error: %v", err)
+	}
+	if _, err := store.Load(); err != ErrNoToken {
+		t.Errorf("MemoryTokenStore.Load() of a deleted token returned %v, want %v", err, ErrNoToken)
+	}
+}
+
+func TestFileTokenStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := NewFileTokenStore(path)
+
+	if _, err := store.Load(); err != ErrNoToken {
+		t.Errorf("FileTokenStore.Load() of a missing file returned %v, want %v", err, ErrNoToken)
+	}
+
+	if err := store.Save(testAccessToken); err != nil {
+		t.Fatalf("FileTokenStore.Save() returned error: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("FileTokenStore.Save() wrote no file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("FileTokenStore.Save() wrote a file with permissions %v, want %v", perm, os.FileMode(0600))
+	}
+
+	token, err := store.Load()
+	if err != nil {
+		t.Fatalf("FileTokenStore.Load() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(token, testAccessToken) {
+		t.Errorf("FileTokenStore.Load() returned %+v, want %+v", token, testAccessToken)
+	}
+
+	if err := store.Delete(); err != nil {
+		t.Fatalf("FileTokenStore.Delete() returned error: %v", err)
+	}
+	if err := store.Delete(); err != nil {
+		t.Errorf("FileTokenStore.Delete() of a missing file returned error: %v", err)
+	}
+}
+
+func TestFileTokenStore_encrypted(t *testing.T) {
+	defer func(n int) { tokenKeyIterations = n }(tokenKeyIterations)
+	tokenKeyIterations = 1000
+
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := NewEncryptedFileTokenStore(path, "test passphrase")
+
+	if err := store.Save(testAccessToken); err != nil {
+		t.Fatalf("FileTokenStore.Save() returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("FileTokenStore.Save() wrote no file: %v", err)
+	}
+	if strings.Contains(string(data), testAccessToken.Secret) {
+		t.Errorf("FileTokenStore.Save() wrote the secret in plain text: %s", data)
+	}
+
+	token, err := store.Load()
+	if err != nil {
+		t.Fatalf("FileTokenStore.Load() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(token, testAccessToken) {
+		t.Errorf("FileTokenStore.Load() returned %+v, want %+v", token, testAccessToken)
+	}
+
+	if _, err := NewEncryptedFileTokenStore(path, "wrong").Load(); err != ErrBadPassphrase {
+		t.Errorf("FileTokenStore.Load() with a wrong passphrase returned %v, want %v", err, ErrBadPassphrase)
+	}
+
+	if _, err := NewFileTokenStore(path).Load(); err == nil {
+		t.Errorf("FileTokenStore.Load() without a passphrase returned no error")
+	}
+}
+
+func TestClient_AuthorizeClientWithStore(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/account/verify_credentials.json", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.Header.Get("Authorization"), `oauth_token="revoked_token"`) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": "revoked"}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"id": "test_user_id"}`)
+	})
+
+	flows := 0
+	flow := func(ctx context.Context, c *Client) (*AccessToken, error) {
+		flows++
+		if err := c.AuthorizeClientWithAccessTokens(testAccessToken.Token, testAccessToken.Secret, nil); err != nil {
+			return nil, err
+		}
+		return testAccessToken, nil
+	}
+
+	store := NewMemoryTokenStore()
+
+	tests := []struct {
+		stored *AccessToken
+		flows  int
+	}{
+		{nil, 1},
+		{testAccessToken, 0},
+		{&AccessToken{Token: "revoked_token", Secret: "test_secret"}, 1},
+	}
+
+	for _, tt := range tests {
+		flows = 0
+		store.Delete()
+		if tt.stored != nil {
+			store.Save(tt.stored)
+		}
+
+		user, err := client.AuthorizeClientWithStore(store, flow)
+		if err != nil {
+			t.Fatalf("AuthorizeClientWithStore with %+v stored returned error: %v", tt.stored, err)
+		}
+		if user.ID != "test_user_id" {
+			t.Errorf("AuthorizeClientWithStore returned %+v, want ID %v", user, "test_user_id")
+		}
+		if flows != tt.flows {
+			t.Errorf("AuthorizeClientWithStore with %+v stored ran the flow %d times, want %d", tt.stored, flows, tt.flows)
+		}
+
+		if token, _ := store.Load(); !reflect.DeepEqual(token, testAccessToken) {
+			t.Errorf("AuthorizeClientWithStore left %+v in the store, want %+v", token, testAccessToken)
+		}
+	}
+
+	failing := func(ctx context.Context, c *Client) (*AccessToken, error) {
+		return nil, errors.New("test error")
+	}
+
+	store.Delete()
+	if _, err := client.AuthorizeClientWithStore(store, failing); err == nil || err.Error() != "test error" {
+		t.Errorf("AuthorizeClientWithStore with a failing flow returned %v, want %v", err, "test error")
+	}
+
+	// A rejected token is not kept when the flow fails
+	store.Save(&AccessToken{Token: "revoked_token", Secret: "test_secret"})
+	if _, err := client.AuthorizeClientWithStore(store, failing); err == nil || err.Error() != "test error" {
+		t.Errorf("AuthorizeClientWithStore with a failing flow returned %v, want %v", err, "test error")
+	}
+	if token, err := store.Load(); !errors.Is(err, ErrNoToken) {
+		t.Errorf("AuthorizeClientWithStore left %+v in the store after the flow failed, want none", token)
+	}
+}
@@ -1,6 +1,7 @@
 package fanfou
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -15,7 +16,7 @@ type TrendsService struct {
 
 // TrendsResult specifies Fanfou's trends data structure
 type TrendsResult struct {
-	AsOf   string        `json:"as_of,omitempty"`
+	AsOf   *Timestamp    `json:"as_of,omitempty"`
 	Trends []*TrendsItem `json:"trends,omitempty"`
 }
 
@@ -30,13 +31,23 @@ type TrendsItem struct {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/trends.list
 func (s *TrendsService) List() (*TrendsResult, *string, error) {
+	return s.ListContext(context.Background())
+}
+
+// ListContext is the same as List, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *TrendsService) ListContext(ctx context.Context) (*TrendsResult, *string, error) {
 	u := fmt.Sprintf("trends/list.json")
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
 
 	trends := new(TrendsResult)
 	resp, err := s.client.Do(req, trends)
-	return trends, resp.BodyStrPtr, err
+	if err != nil {
+		return nil, resp.body(), err
+	}
+
+	return trends, resp.BodyStrPtr, nil
 }
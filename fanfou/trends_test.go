@@ -25,7 +25,7 @@ func TestTrendsService_List(t *testing.T) {
 	}
 
 	want := &TrendsResult{
-		AsOf: "Thu Nov 10 09:57:23 +0000 2011",
+		AsOf: mustParseTimestamp("Thu Nov 10 09:57:23 +0000 2011"),
 		Trends: []*TrendsItem{
 			{
 				Name:  "萤火一号",
@@ -1,10 +1,12 @@
 package fanfou
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // UsersService handles communication with the users related
@@ -35,7 +37,7 @@ type UserResult struct {
 	StatusesCount             int64         `json:"statuses_count,omitempty"`
 	Following                 bool          `json:"following,omitempty"`
 	Notifications             bool          `json:"notifications,omitempty"`
-	CreatedAt                 string        `json:"created_at,omitempty"`
+	CreatedAt                 *Timestamp    `json:"created_at,omitempty"`
 	UtcOffset                 int64         `json:"utc_offset,omitempty"`
 	ProfileBackgroundColor    string        `json:"profile_background_color,omitempty"`
 	ProfileTextColor          string        `json:"profile_text_color,omitempty"`
@@ -50,6 +52,59 @@ type UserResult struct {
 	Status                    *StatusResult `json:"status,omitempty"`
 }
 
+// GetID returns the ID of the user, or an empty string if u is nil
+func (u *UserResult) GetID() string {
+	if u == nil {
+		return ""
+	}
+
+	return u.ID
+}
+
+// GetName returns the name of the user, or an empty string if u is nil
+func (u *UserResult) GetName() string {
+	if u == nil {
+		return ""
+	}
+
+	return u.Name
+}
+
+// GetScreenName returns the screen name of the user, or an empty string if
+// u is nil
+func (u *UserResult) GetScreenName() string {
+	if u == nil {
+		return ""
+	}
+
+	return u.ScreenName
+}
+
+// GetProfileImageURL returns the profile image URL of the user, or an
+// empty string if u is nil
+func (u *UserResult) GetProfileImageURL() string {
+	if u == nil {
+		return ""
+	}
+
+	return u.ProfileImageURL
+}
+
+// IsProtected reports whether the user is protected, false if u is nil
+func (u *UserResult) IsProtected() bool {
+	return u != nil && u.Protected
+}
+
+// TimeZone returns the time zone of the user, from its UTC offset, or UTC
+// if u is nil
+func (u *UserResult) TimeZone() *time.Location {
+	if u == nil {
+		return time.UTC
+	}
+
+	return time.FixedZone("", int(u.UtcOffset))
+}
+
 // Tag specifies Fanfou's tags data structure
 type Tag string
 
@@ -66,6 +121,12 @@ type UsersOptParams struct {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/users.tagged
 func (s *UsersService) Tagged(Tag string, opt *UsersOptParams) ([]UserResult, *string, error) {
+	return s.TaggedContext(context.Background(), Tag, opt)
+}
+
+// TaggedContext is the same as Tagged, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *UsersService) TaggedContext(ctx context.Context, Tag string, opt *UsersOptParams) ([]UserResult, *string, error) {
 	u := fmt.Sprintf("users/tagged.json")
 	params := url.Values{}
 	params.Add("tag", Tag)
@@ -87,7 +148,7 @@ func (s *UsersService) Tagged(Tag string, opt *UsersOptParams) ([]UserResult, *s
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -95,7 +156,7 @@ func (s *UsersService) Tagged(Tag string, opt *UsersOptParams) ([]UserResult, *s
 	newUsers := new([]UserResult)
 	resp, err := s.client.Do(req, newUsers)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newUsers, resp.BodyStrPtr, nil
@@ -107,6 +168,12 @@ func (s *UsersService) Tagged(Tag string, opt *UsersOptParams) ([]UserResult, *s
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/users.show
 func (s *UsersService) Show(opt *UsersOptParams) (*UserResult, *string, error) {
+	return s.ShowContext(context.Background(), opt)
+}
+
+// ShowContext is the same as Show, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *UsersService) ShowContext(ctx context.Context, opt *UsersOptParams) (*UserResult, *string, error) {
 	u := fmt.Sprintf("users/show.json")
 	params := url.Values{}
 
@@ -124,7 +191,7 @@ func (s *UsersService) Show(opt *UsersOptParams) (*UserResult, *string, error) {
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -132,7 +199,7 @@ func (s *UsersService) Show(opt *UsersOptParams) (*UserResult, *string, error) {
 	newUser := new(UserResult)
 	resp, err := s.client.Do(req, newUser)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUser, resp.BodyStrPtr, nil
@@ -142,6 +209,12 @@ func (s *UsersService) Show(opt *UsersOptParams) (*UserResult, *string, error) {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/users.tag-list
 func (s *UsersService) TagList(opt *UsersOptParams) ([]Tag, *string, error) {
+	return s.TagListContext(context.Background(), opt)
+}
+
+// TagListContext is the same as TagList, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *UsersService) TagListContext(ctx context.Context, opt *UsersOptParams) ([]Tag, *string, error) {
 	u := fmt.Sprintf("users/tag_list.json")
 	params := url.Values{}
 
@@ -153,7 +226,7 @@ func (s *UsersService) TagList(opt *UsersOptParams) ([]Tag, *string, error) {
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -161,7 +234,7 @@ func (s *UsersService) TagList(opt *UsersOptParams) ([]Tag, *string, error) {
 	newTags := new([]Tag)
 	resp, err := s.client.Do(req, newTags)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newTags, resp.BodyStrPtr, nil
@@ -171,6 +244,12 @@ func (s *UsersService) TagList(opt *UsersOptParams) ([]Tag, *string, error) {
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/users.followers
 func (s *UsersService) Followers(opt *UsersOptParams) ([]UserResult, *string, error) {
+	return s.FollowersContext(context.Background(), opt)
+}
+
+// FollowersContext is the same as Followers, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *UsersService) FollowersContext(ctx context.Context, opt *UsersOptParams) ([]UserResult, *string, error) {
 	u := fmt.Sprintf("users/followers.json")
 	params := url.Values{}
 
@@ -194,7 +273,7 @@ func (s *UsersService) Followers(opt *UsersOptParams) ([]UserResult, *string, er
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -202,7 +281,7 @@ func (s *UsersService) Followers(opt *UsersOptParams) ([]UserResult, *string, er
 	newUsers := new([]UserResult)
 	resp, err := s.client.Do(req, newUsers)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newUsers, resp.BodyStrPtr, nil
@@ -214,6 +293,12 @@ func (s *UsersService) Followers(opt *UsersOptParams) ([]UserResult, *string, er
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/users.friends
 func (s *UsersService) Friends(opt *UsersOptParams) ([]UserResult, *string, error) {
+	return s.FriendsContext(context.Background(), opt)
+}
+
+// FriendsContext is the same as Friends, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *UsersService) FriendsContext(ctx context.Context, opt *UsersOptParams) ([]UserResult, *string, error) {
 	u := fmt.Sprintf("users/friends.json")
 	params := url.Values{}
 
@@ -237,7 +322,7 @@ func (s *UsersService) Friends(opt *UsersOptParams) ([]UserResult, *string, erro
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -245,7 +330,7 @@ func (s *UsersService) Friends(opt *UsersOptParams) ([]UserResult, *string, erro
 	newUsers := new([]UserResult)
 	resp, err := s.client.Do(req, newUsers)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newUsers, resp.BodyStrPtr, nil
@@ -255,6 +340,12 @@ func (s *UsersService) Friends(opt *UsersOptParams) ([]UserResult, *string, erro
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/users.recommendation
 func (s *UsersService) Recommendation(opt *UsersOptParams) ([]UserResult, *string, error) {
+	return s.RecommendationContext(context.Background(), opt)
+}
+
+// RecommendationContext is the same as Recommendation, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *UsersService) RecommendationContext(ctx context.Context, opt *UsersOptParams) ([]UserResult, *string, error) {
 	u := fmt.Sprintf("2/users/recommendation.json")
 	params := url.Values{}
 
@@ -275,7 +366,7 @@ func (s *UsersService) Recommendation(opt *UsersOptParams) ([]UserResult, *strin
 
 	u += "?" + params.Encode()
 
-	req, err := s.client.NewRequest(http.MethodGet, u, "")
+	req, err := s.client.NewRequestContext(ctx, http.MethodGet, u, "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -283,7 +374,7 @@ func (s *UsersService) Recommendation(opt *UsersOptParams) ([]UserResult, *strin
 	newUsers := new([]UserResult)
 	resp, err := s.client.Do(req, newUsers)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return *newUsers, resp.BodyStrPtr, nil
@@ -295,6 +386,12 @@ func (s *UsersService) Recommendation(opt *UsersOptParams) ([]UserResult, *strin
 //
 // Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/users.cancel-recommendation
 func (s *UsersService) CancelRecommendation(ID string, opt *UsersOptParams) (*UserResult, *string, error) {
+	return s.CancelRecommendationContext(context.Background(), ID, opt)
+}
+
+// CancelRecommendationContext is the same as CancelRecommendation, except that the request
+// is bound to ctx and aborted once ctx is done
+func (s *UsersService) CancelRecommendationContext(ctx context.Context, ID string, opt *UsersOptParams) (*UserResult, *string, error) {
 	u := fmt.Sprintf("2/users/cancel_recommendation.json")
 	params := url.Values{
 		"id": []string{ID},
@@ -309,7 +406,7 @@ func (s *UsersService) CancelRecommendation(ID string, opt *UsersOptParams) (*Us
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, params.Encode())
+	req, err := s.client.NewRequestContext(ctx, http.MethodPost, u, params.Encode())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -317,7 +414,7 @@ func (s *UsersService) CancelRecommendation(ID string, opt *UsersOptParams) (*Us
 	newUser := new(UserResult)
 	resp, err := s.client.Do(req, newUser)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp.body(), err
 	}
 
 	return newUser, resp.BodyStrPtr, nil
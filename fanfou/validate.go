@@ -0,0 +1,129 @@
+package fanfou
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MaxClockSkew is the difference between the local clock and the clock of
+// Fanfou beyond which the timestamps of the OAuth signatures are rejected
+const MaxClockSkew = 5 * time.Minute
+
+// Reasons of a ValidationError, which it can be matched against with
+// errors.Is
+var (
+	// ErrTokenRevoked is the reason of the rejection of an access token
+	// which was revoked, or never valid
+	ErrTokenRevoked = errors.New("fanfou: access token revoked or invalid")
+
+	// ErrBadSignature is the reason of the rejection of an OAuth signature,
+	// e.g. because of a wrong consumer secret or token secret
+	ErrBadSignature = errors.New("fanfou: bad OAuth signature")
+
+	// ErrClockSkew is the reason of the rejection of an OAuth timestamp, as
+	// the local clock is too far from the clock of Fanfou
+	ErrClockSkew = errors.New("fanfou: local clock is out of sync")
+)
+
+// badSignatureMarkers are the fragments of the error messages Fanfou
+// returns when an OAuth signature is rejected
+var badSignatureMarkers = []string{
+	"signature",
+	"签名",
+}
+
+// clockSkewMarkers are the fragments of the error messages Fanfou returns
+// when an OAuth timestamp or nonce is rejected
+var clockSkewMarkers = []string{
+	"timestamp",
+	"nonce",
+	"时间",
+}
+
+// ValidationError is returned by Client.Validate when the credentials of
+// the client are rejected
+type ValidationError struct {
+	// Reason is why the credentials were rejected: ErrTokenRevoked,
+	// ErrBadSignature or ErrClockSkew
+	Reason error
+
+	// Skew is the time of Fanfou minus the local time, if Fanfou sent it
+	Skew time.Duration
+
+	// Err is the error of account/verify_credentials
+	Err error
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	msg := fmt.Sprintf("%v: %v", e.Reason, e.Err)
+	if e.Reason == ErrClockSkew && e.Skew != 0 {
+		msg += fmt.Sprintf(" (skew %v)", e.Skew.Round(time.Second))
+	}
+
+	return msg
+}
+
+// Unwrap returns the reason and the error of account/verify_credentials,
+// so that both can be matched with errors.Is and errors.As
+func (e *ValidationError) Unwrap() []error {
+	return []error{e.Reason, e.Err}
+}
+
+// Validate shall check that the credentials of the client are accepted,
+// and return the user it is authorized as. If they are rejected, a
+// *ValidationError tells why; any other error, e.g. of the network, is
+// returned as is.
+//
+// Fanfou API docs: https://github.com/mogita/FanFouAPIDoc/wiki/account.verify-credentials
+func (c *Client) Validate(ctx context.Context) (*UserResult, error) {
+	if c.AccessToken() == nil {
+		return nil, ErrNotAuthorized
+	}
+
+	user, _, err := c.Account.VerifyCredentialsContext(ctx, nil)
+	if err == nil {
+		return user, nil
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.StatusCode() != http.StatusUnauthorized {
+		return nil, err
+	}
+
+	verr := &ValidationError{Reason: ErrTokenRevoked, Err: err}
+	if date, dateErr := http.ParseTime(errResp.Response.Header.Get("Date")); dateErr == nil {
+		verr.Skew = date.Sub(time.Now()).Truncate(time.Second)
+	}
+
+	msg := ""
+	if errResp.Meta != nil {
+		msg = strings.ToLower(errResp.Meta.Error)
+	}
+
+	switch {
+	case verr.Skew > MaxClockSkew || verr.Skew < -MaxClockSkew || containsAny(msg, clockSkewMarkers):
+		verr.Reason = ErrClockSkew
+	case containsAny(msg, badSignatureMarkers):
+		verr.Reason = ErrBadSignature
+	}
+
+	return nil, verr
+}
+
+// AccessToken returns the access token the client is authorized with, e.g.
+// to store it, or nil if the client is not authorized
+func (c *Client) AccessToken() *AccessToken {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.accessToken == nil {
+		return nil
+	}
+
+	return copyToken(newAccessToken(c.accessToken))
+}
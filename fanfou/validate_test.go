@@ -0,0 +1,97 @@
+package fanfou
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_Validate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var status int
+	var message string
+	var skew time.Duration
+
+	mux.HandleFunc("/account/verify_credentials.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Date", time.Now().Add(skew).UTC().Format(http.TimeFormat))
+
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			fmt.Fprintf(w, `{"error": %q}`, message)
+			return
+		}
+
+		fmt.Fprint(w, `{"id": "test_user_id"}`)
+	})
+
+	status = http.StatusOK
+	user, err := client.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if user.ID != "test_user_id" {
+		t.Errorf("Validate returned %+v, want ID %v", user, "test_user_id")
+	}
+
+	tests := []struct {
+		message string
+		skew    time.Duration
+		want    error
+	}{
+		{"Invalid token", 0, ErrTokenRevoked},
+		{"Invalid signature", 0, ErrBadSignature},
+		{"Invalid signature", time.Hour, ErrClockSkew},
+		{"Invalid timestamp", 0, ErrClockSkew},
+	}
+
+	for _, tt := range tests {
+		status, message, skew = http.StatusUnauthorized, tt.message, tt.skew
+
+		_, err := client.Validate(context.Background())
+
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Fatalf("Validate with %q returned %v, want a ValidationError", tt.message, err)
+		}
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Validate with %q and skew %v returned reason %v, want %v", tt.message, tt.skew, verr.Reason, tt.want)
+		}
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("Validate with %q returned %v, want it to match ErrUnauthorized", tt.message, err)
+		}
+		if diff := verr.Skew - tt.skew; diff < -2*time.Second || diff > 2*time.Second {
+			t.Errorf("Validate with skew %v returned skew %v", tt.skew, verr.Skew)
+		}
+	}
+
+	status, skew = http.StatusInternalServerError, 0
+	if _, err := client.Validate(context.Background()); err == nil || errors.As(err, new(*ValidationError)) {
+		t.Errorf("Validate with a server error returned %v, want it as is", err)
+	}
+
+	if _, err := NewClient("test", "test").Validate(context.Background()); err != ErrNotAuthorized {
+		t.Errorf("Validate of an unauthorized client returned %v, want %v", err, ErrNotAuthorized)
+	}
+}
+
+func TestClient_AccessToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &AccessToken{Token: "test", Secret: "test", AdditionalData: map[string]string{}}
+
+	token := client.AccessToken()
+	if token == nil || token.Token != want.Token || token.Secret != want.Secret {
+		t.Errorf("AccessToken() returned %+v, want %+v", token, want)
+	}
+
+	if token := NewClient("test", "test").AccessToken(); token != nil {
+		t.Errorf("AccessToken() of an unauthorized client returned %+v, want nil", token)
+	}
+}
@@ -1,5 +1,8 @@
 module github.com/mogita/go-fanfou
 
-go 1.12
+go 1.23.0
 
-require github.com/mogita/oauth v0.0.0-20190804151539-f4354877fe9e
+require (
+	github.com/mogita/oauth v0.0.0-20190804151539-f4354877fe9e
+	golang.org/x/crypto v0.36.0
+)